@@ -18,4 +18,34 @@ integration {
     name = "Oracle Cloud Infrastructure Classic Compute"
     slug = "classic"
   }
+  component {
+    type = "data-source"
+    name = "Oracle Cloud Infrastructure Availability Domains"
+    slug = "oci-availability-domains"
+  }
+  component {
+    type = "data-source"
+    name = "Oracle Cloud Infrastructure Image"
+    slug = "oci-image"
+  }
+  component {
+    type = "data-source"
+    name = "Oracle Cloud Infrastructure Vault Secret"
+    slug = "oci-secret"
+  }
+  component {
+    type = "data-source"
+    name = "Oracle Cloud Infrastructure Subnet"
+    slug = "oci-subnet"
+  }
+  component {
+    type = "post-processor"
+    name = "Oracle Cloud Infrastructure Image Export"
+    slug = "oci-export"
+  }
+  component {
+    type = "post-processor"
+    name = "Oracle Cloud Infrastructure Image Import"
+    slug = "oci-import"
+  }
 }
@@ -0,0 +1,213 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"github.com/hashicorp/packer-plugin-sdk/pathing"
+	ocicommon "github.com/oracle/oci-go-sdk/v65/common"
+	ociauth "github.com/oracle/oci-go-sdk/v65/common/auth"
+)
+
+// AccessConfig holds the options used to authenticate against the OCI API.
+// It is shared by the builder and the OCI post-processors.
+type AccessConfig struct {
+	configProvider ocicommon.ConfigurationProvider
+
+	// Instance Principals (OPTIONAL)
+	// If set to true the following can't have non empty values
+	// - AccessCfgFile
+	// - AccessCfgFileAccount
+	// - UserID
+	// - TenancyID
+	// - Region
+	// - Fingerprint
+	// - KeyFile
+	// - PassPhrase
+	InstancePrincipals bool `mapstructure:"use_instance_principals"`
+
+	AccessCfgFile        string `mapstructure:"access_cfg_file"`
+	AccessCfgFileAccount string `mapstructure:"access_cfg_file_account"`
+
+	// Access config overrides
+	UserID      string `mapstructure:"user_ocid"`
+	TenancyID   string `mapstructure:"tenancy_ocid"`
+	Region      string `mapstructure:"region"`
+	Fingerprint string `mapstructure:"fingerprint"`
+	Key         string `mapstructure:"key"`
+	KeyFile     string `mapstructure:"key_file"`
+	PassPhrase  string `mapstructure:"pass_phrase"`
+
+	SecurityTokenFilePath string `mapstructure:"security_token_file"`
+}
+
+func (c *AccessConfig) ConfigProvider() ocicommon.ConfigurationProvider {
+	return c.configProvider
+}
+
+// Prepare validates the access options and sets up the configuration
+// provider used by the OCI clients.
+func (c *AccessConfig) Prepare() []error {
+	var errs []error
+	var err error
+
+	if c.InstancePrincipals {
+		// We could go through all keys in one go and report that the below set
+		// of keys cannot coexist with use_instance_principals but decided to
+		// split them and report them seperately so that the user sees the specific
+		// key involved.
+		var message string = " cannot be present when use_instance_principals is set to true."
+		if c.AccessCfgFile != "" {
+			errs = append(errs, errors.New("access_cfg_file"+message))
+		}
+		if c.AccessCfgFileAccount != "" {
+			errs = append(errs, errors.New("access_cfg_file_account"+message))
+		}
+		if c.UserID != "" {
+			errs = append(errs, errors.New("user_ocid"+message))
+		}
+		if c.TenancyID != "" {
+			errs = append(errs, errors.New("tenancy_ocid"+message))
+		}
+		if c.Region != "" {
+			errs = append(errs, errors.New("region"+message))
+		}
+		if c.Fingerprint != "" {
+			errs = append(errs, errors.New("fingerprint"+message))
+		}
+		if c.Key != "" {
+			errs = append(errs, errors.New("key"+message))
+		}
+		if c.KeyFile != "" {
+			errs = append(errs, errors.New("key_file"+message))
+		}
+		if c.PassPhrase != "" {
+			errs = append(errs, errors.New("pass_phrase"+message))
+		}
+		// This check is used to facilitate testing. During testing a Mock struct
+		// is assigned to c.configProvider otherwise testing fails because Instance
+		// Principals cannot be obtained.
+		if c.configProvider == nil {
+			// Even though the previous configuration checks might fail we don't want
+			// to skip this step. It seems that the logic behind the checks in this
+			// file is to check everything even getting the configProvider.
+			c.configProvider, err = ociauth.InstancePrincipalConfigurationProvider()
+			if err != nil {
+				return append(errs, err)
+			}
+		}
+		if _, err := c.configProvider.TenancyOCID(); err != nil {
+			return append(errs, err)
+		}
+
+		return errs
+	}
+
+	// Determine where the SDK config is located
+	if c.AccessCfgFile == "" {
+		c.AccessCfgFile, err = getDefaultOCISettingsPath()
+		if err != nil {
+			log.Println("Default OCI settings file not found")
+		}
+	}
+
+	if c.AccessCfgFileAccount == "" {
+		c.AccessCfgFileAccount = "DEFAULT"
+	}
+
+	// Read API signing key
+	var keyContent []byte
+	if c.KeyFile != "" {
+		path, err := pathing.ExpandUser(c.KeyFile)
+		if err != nil {
+			return append(errs, err)
+		}
+
+		// Read API signing key
+		keyContent, err = ioutil.ReadFile(path)
+		if err != nil {
+			return append(errs, err)
+		}
+	}
+	if c.Key != "" {
+		keyContent = []byte(c.Key)
+	}
+
+	// Providers
+	fileProvider, _ := ocicommon.ConfigurationProviderFromFileWithProfile(c.AccessCfgFile, c.AccessCfgFileAccount, c.PassPhrase)
+	if c.Region == "" {
+		var region string
+		if fileProvider != nil {
+			region, _ = fileProvider.Region()
+		}
+		if region == "" {
+			c.Region = "us-phoenix-1"
+		}
+	}
+
+	providers := []ocicommon.ConfigurationProvider{
+		ocicommon.NewRawConfigurationProvider(c.TenancyID, c.UserID, c.Region, c.Fingerprint, string(keyContent), &c.PassPhrase),
+	}
+
+	if fileProvider != nil {
+		providers = append(providers, fileProvider)
+	}
+
+	// Load API access configuration from SDK
+	configProvider, err := ocicommon.ComposingConfigurationProvider(providers)
+	if err != nil {
+		return append(errs, err)
+	}
+
+	if tenancyOCID, _ := configProvider.TenancyOCID(); tenancyOCID == "" {
+		errs = append(errs, errors.New("'tenancy_ocid' must be specified"))
+	}
+
+	if fingerprint, _ := configProvider.KeyFingerprint(); fingerprint == "" {
+		errs = append(errs, errors.New("'fingerprint' must be specified"))
+	}
+
+	if _, err := configProvider.UserOCID(); err != nil {
+		errs = append(errs, fmt.Errorf("'user_ocid' must be correctly specified. %w", err))
+	}
+
+	if _, err := configProvider.KeyID(); err != nil {
+		errs = append(errs, fmt.Errorf("'security_token_file' must be correctly specified. %w", err))
+	}
+
+	if _, err := configProvider.PrivateRSAKey(); err != nil {
+		errs = append(errs, fmt.Errorf("'key_file' must be correctly specified. %w", err))
+	}
+
+	c.configProvider = configProvider
+
+	return errs
+}
+
+// getDefaultOCISettingsPath uses os/user to compute the default
+// config file location ($HOME/.oci/config).
+func getDefaultOCISettingsPath() (string, error) {
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+
+	if u.HomeDir == "" {
+		return "", fmt.Errorf("Unable to determine the home directory for the current user.")
+	}
+
+	path := filepath.Join(u.HomeDir, ".oci", "config")
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
@@ -7,7 +7,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/packer-plugin-sdk/packer/registry/image"
 	"github.com/oracle/oci-go-sdk/v65/core"
@@ -15,15 +17,38 @@ import (
 
 // Artifact is an artifact implementation that contains a built Custom Image.
 type Artifact struct {
-	Image  core.Image
-	Region string
-	driver Driver
+	// Image is the custom image created in the build region. Copies in other
+	// regions share its metadata.
+	Image core.Image
+	// Images maps each region to the OCID of the custom image in that region.
+	Images map[string]string
+	// BootVolumeBackupID is the OCID of the backup of the instance's boot
+	// volume, if one was requested.
+	BootVolumeBackupID string
+	// VolumeBackupIDs are the OCIDs of the backups of the block volumes
+	// that were created with keep_backup.
+	VolumeBackupIDs []string
+	// KmsKeyID is the OCID of the customer-managed key that encrypted the
+	// boot volume of the build instance, if one was configured.
+	KmsKeyID string
+	driver   Driver
 
 	// StateData should store data such as GeneratedData
 	// to be shared with post-processors
 	StateData map[string]interface{}
 }
 
+// NewArtifact returns an artifact for images created outside of the builder,
+// such as by the OCI post-processors.
+func NewArtifact(img core.Image, images map[string]string, driver Driver, stateData map[string]interface{}) *Artifact {
+	return &Artifact{
+		Image:     img,
+		Images:    images,
+		driver:    driver,
+		StateData: stateData,
+	}
+}
+
 // BuilderId uniquely identifies the builder.
 func (a *Artifact) BuilderId() string {
 	return BuilderId
@@ -35,34 +60,113 @@ func (a *Artifact) Files() []string {
 	return nil
 }
 
-// Id returns the OCID of the associated Image.
+// Id returns the OCID of the custom image in the build region, or of the boot
+// volume backup when no image was created. The images copied to other
+// regions are available through the "images" state.
 func (a *Artifact) Id() string {
-	return *a.Image.Id
+	if a.Image.Id != nil {
+		return *a.Image.Id
+	}
+
+	return a.BootVolumeBackupID
 }
 
 func (a *Artifact) String() string {
-	var displayName string
-	if a.Image.DisplayName != nil {
-		displayName = *a.Image.DisplayName
+	var parts []string
+
+	if len(a.Images) > 0 {
+		var displayName string
+		if a.Image.DisplayName != nil {
+			displayName = *a.Image.DisplayName
+		}
+
+		images := make([]string, 0, len(a.Images))
+		for _, region := range a.regions() {
+			images = append(images, fmt.Sprintf("%s: %s", region, a.Images[region]))
+		}
+
+		parts = append(parts, fmt.Sprintf(
+			"An image was created: '%v' in the following regions:\n%s",
+			displayName, strings.Join(images, "\n"),
+		))
+	}
+
+	if a.BootVolumeBackupID != "" {
+		parts = append(parts, fmt.Sprintf("A boot volume backup was created: %s", a.BootVolumeBackupID))
 	}
 
-	return fmt.Sprintf(
-		"An image was created: '%v' (OCID: %v) in region '%v'",
-		displayName, *a.Image.Id, a.Region,
-	)
+	if len(a.VolumeBackupIDs) > 0 {
+		parts = append(parts, fmt.Sprintf("Block volume backups were created:\n%s", strings.Join(a.VolumeBackupIDs, "\n")))
+	}
+
+	if a.KmsKeyID != "" {
+		parts = append(parts, fmt.Sprintf("The boot volume was encrypted with KMS key: %s", a.KmsKeyID))
+	}
+
+	return strings.Join(parts, "\n")
 }
 
 func (a *Artifact) State(name string) interface{} {
-	if name == image.ArtifactStateURI {
+	switch name {
+	case image.ArtifactStateURI:
 		return a.buildHCPackerRegistryMetadata()
+	case "images":
+		// The region to OCID map lets post-processors pick the image living
+		// in the region they operate in.
+		return a.Images
+	case "boot_volume_backup_id":
+		return a.BootVolumeBackupID
+	case "volume_backup_ids":
+		return a.VolumeBackupIDs
+	case "kms_key_id":
+		return a.KmsKeyID
 	}
 
 	return a.StateData[name]
 }
 
-// Destroy deletes the custom image associated with the artifact.
+// Destroy deletes the custom image associated with the artifact in every
+// region it was copied to, as well as the boot and block volume backups.
 func (a *Artifact) Destroy() error {
-	return a.driver.DeleteImage(context.TODO(), *a.Image.Id)
+	var errs []string
+	for _, region := range a.regions() {
+		id := a.Images[region]
+		log.Printf("Deleting image %s in region %s", id, region)
+		if err := a.driver.DeleteImageInRegion(context.TODO(), region, id); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", id, err))
+		}
+	}
+
+	if a.BootVolumeBackupID != "" {
+		log.Printf("Deleting boot volume backup %s", a.BootVolumeBackupID)
+		if err := a.driver.DeleteBootVolumeBackup(context.TODO(), a.BootVolumeBackupID); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", a.BootVolumeBackupID, err))
+		}
+	}
+
+	for _, id := range a.VolumeBackupIDs {
+		log.Printf("Deleting block volume backup %s", id)
+		if err := a.driver.DeleteVolumeBackup(context.TODO(), id); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", id, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Error deleting artifact: %s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// regions returns the regions of the artifact's images in sorted order.
+func (a *Artifact) regions() []string {
+	regions := make([]string, 0, len(a.Images))
+	for region := range a.Images {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	return regions
 }
 
 func (a *Artifact) buildHCPackerRegistryMetadata() interface{} {
@@ -81,6 +185,10 @@ func (a *Artifact) buildHCPackerRegistryMetadata() interface{} {
 		labels["launch_mode"] = string(a.Image.LaunchMode)
 	}
 
+	if a.KmsKeyID != "" {
+		labels["kms_key_id"] = a.KmsKeyID
+	}
+
 	if a.Image.OperatingSystem != nil {
 		labels["operating_system"] = *a.Image.OperatingSystem
 	}
@@ -89,12 +197,29 @@ func (a *Artifact) buildHCPackerRegistryMetadata() interface{} {
 		labels["operating_system_version"] = *a.Image.OperatingSystemVersion
 	}
 
-	img, err := image.FromArtifact(a, image.WithRegion(a.Region), image.WithSourceID(*a.Image.BaseImageId), image.SetLabels(labels))
+	opts := []image.ArtifactOverrideFunc{image.SetLabels(labels)}
+	// Imported images have no base image.
+	if a.Image.BaseImageId != nil {
+		opts = append(opts, image.WithSourceID(*a.Image.BaseImageId))
+	}
+
+	imgs, err := image.FromMappedData(a.Images, func(key, value interface{}) (*image.Image, error) {
+		region, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type for region %T", key)
+		}
+		id, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type for image id %T", value)
+		}
+
+		return image.FromArtifact(a, append([]image.ArtifactOverrideFunc{image.WithID(id), image.WithRegion(region)}, opts...)...)
+	})
 
 	if err != nil {
 		log.Printf("[TRACE] error encountered when creating HCP Packer registry image for artifact: %s", err)
 		return nil
 	}
 
-	return img
+	return imgs
 }
@@ -5,6 +5,7 @@ package oci
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
@@ -57,27 +58,154 @@ func TestArtifactState_hcpPackerRegistryMetadata(t *testing.T) {
 			LaunchMode:             core.ImageLaunchModeParavirtualized,
 			BillableSizeInGBs:      int64Ptr(10),
 		},
-		Region: "us-phoenix-1",
+		Images: map[string]string{
+			"us-phoenix-1": "ocid1.image.oc1.phx.aaa",
+			"us-ashburn-1": "ocid1.image.oc1.iad.aaa",
+		},
 	}
 
 	result := artifact.State(image.ArtifactStateURI)
 
-	expected := &image.Image{
-		ImageID:        "ocid1.image.oc1.phx.aaa",
-		ProviderName:   BuilderId,
-		ProviderRegion: "us-phoenix-1",
-		Labels: map[string]string{
-			"billable_size_in_gbs":     "10",
-			"compartment_id":           "ocid1.compartment.oc1..aaa",
-			"launch_mode":              string(core.ImageLaunchModeParavirtualized),
-			"operating_system":         "Oracle Linux",
-			"operating_system_version": "7.2",
+	labels := map[string]string{
+		"billable_size_in_gbs":     "10",
+		"compartment_id":           "ocid1.compartment.oc1..aaa",
+		"launch_mode":              string(core.ImageLaunchModeParavirtualized),
+		"operating_system":         "Oracle Linux",
+		"operating_system_version": "7.2",
+	}
+	expected := map[string]*image.Image{
+		"us-phoenix-1": {
+			ImageID:        "ocid1.image.oc1.phx.aaa",
+			ProviderName:   BuilderId,
+			ProviderRegion: "us-phoenix-1",
+			Labels:         labels,
+			SourceImageID:  "ocid1.image.oc1.phx.aaabase",
+		},
+		"us-ashburn-1": {
+			ImageID:        "ocid1.image.oc1.iad.aaa",
+			ProviderName:   BuilderId,
+			ProviderRegion: "us-ashburn-1",
+			Labels:         labels,
+			SourceImageID:  "ocid1.image.oc1.phx.aaabase",
+		},
+	}
+
+	images, ok := result.([]*image.Image)
+	if !ok {
+		t.Fatalf("Bad: HCP registry metadata was %#v instead of a list of images", result)
+	}
+	if len(images) != len(expected) {
+		t.Fatalf("Bad: got %d HCP registry images instead of %d", len(images), len(expected))
+	}
+	for _, img := range images {
+		if !reflect.DeepEqual(img, expected[img.ProviderRegion]) {
+			t.Fatalf("Bad: HCP registry metadata was %#v instead of %#v", img, expected[img.ProviderRegion])
+		}
+	}
+}
+
+func TestArtifactDestroy(t *testing.T) {
+	driver := &driverMock{}
+	artifact := &Artifact{
+		Images: map[string]string{
+			"us-phoenix-1": "ocid1.image.oc1.phx.aaa",
+			"us-ashburn-1": "ocid1.image.oc1.iad.aaa",
+		},
+		driver: driver,
+	}
+
+	if err := artifact.Destroy(); err != nil {
+		t.Fatalf("Unexpected error destroying artifact: %s", err)
+	}
+
+	if !reflect.DeepEqual(driver.DeleteImageInRegionIDs, artifact.Images) {
+		t.Fatalf("Bad: deleted images %v instead of %v", driver.DeleteImageInRegionIDs, artifact.Images)
+	}
+}
+
+func TestArtifactId(t *testing.T) {
+	artifact := &Artifact{
+		Image: core.Image{Id: stringPtr("ocid1.image.oc1.phx.aaa")},
+		Images: map[string]string{
+			"us-phoenix-1": "ocid1.image.oc1.phx.aaa",
+			"us-ashburn-1": "ocid1.image.oc1.iad.aaa",
+		},
+		BootVolumeBackupID: "ocid1.bootvolumebackup.oc1.phx.aaa",
+	}
+
+	expected := "ocid1.image.oc1.phx.aaa"
+	if id := artifact.Id(); id != expected {
+		t.Fatalf("Bad: artifact id was %s instead of %s", id, expected)
+	}
+
+	if images := artifact.State("images"); !reflect.DeepEqual(images, artifact.Images) {
+		t.Fatalf("Bad: images state was %v instead of %v", images, artifact.Images)
+	}
+}
+
+func TestArtifactBootVolumeBackup(t *testing.T) {
+	driver := &driverMock{}
+	artifact := &Artifact{
+		BootVolumeBackupID: "ocid1.bootvolumebackup.oc1.phx.aaa",
+		driver:             driver,
+	}
+
+	expected := "ocid1.bootvolumebackup.oc1.phx.aaa"
+	if id := artifact.Id(); id != expected {
+		t.Fatalf("Bad: artifact id was %s instead of %s", id, expected)
+	}
+
+	if id := artifact.State("boot_volume_backup_id"); id != artifact.BootVolumeBackupID {
+		t.Fatalf("Bad: boot_volume_backup_id state was %v instead of %s", id, artifact.BootVolumeBackupID)
+	}
+
+	if err := artifact.Destroy(); err != nil {
+		t.Fatalf("Unexpected error destroying artifact: %s", err)
+	}
+
+	if driver.DeleteBootVolumeBackupID != artifact.BootVolumeBackupID {
+		t.Fatalf("Bad: deleted boot volume backup %s instead of %s", driver.DeleteBootVolumeBackupID, artifact.BootVolumeBackupID)
+	}
+}
+
+func TestArtifactVolumeBackups(t *testing.T) {
+	driver := &driverMock{}
+	artifact := &Artifact{
+		VolumeBackupIDs: []string{"ocid1.volumebackup.oc1.phx.aaa", "ocid1.volumebackup.oc1.phx.bbb"},
+		driver:          driver,
+	}
+
+	if ids := artifact.State("volume_backup_ids"); !reflect.DeepEqual(ids, artifact.VolumeBackupIDs) {
+		t.Fatalf("Bad: volume_backup_ids state was %v instead of %v", ids, artifact.VolumeBackupIDs)
+	}
+
+	if !strings.Contains(artifact.String(), "ocid1.volumebackup.oc1.phx.bbb") {
+		t.Fatalf("Bad: volume backups missing from %q", artifact.String())
+	}
+
+	if err := artifact.Destroy(); err != nil {
+		t.Fatalf("Unexpected error destroying artifact: %s", err)
+	}
+
+	if !reflect.DeepEqual(driver.DeleteVolumeBackupIDs, artifact.VolumeBackupIDs) {
+		t.Fatalf("Bad: deleted volume backups %v instead of %v", driver.DeleteVolumeBackupIDs, artifact.VolumeBackupIDs)
+	}
+}
+
+func TestArtifactKmsKey(t *testing.T) {
+	artifact := &Artifact{
+		Images: map[string]string{
+			"us-phoenix-1": "ocid1.image.oc1.phx.aaa",
 		},
-		SourceImageID: "ocid1.image.oc1.phx.aaabase",
+		KmsKeyID: "ocid1.key.oc1.phx.aaa",
+	}
+
+	if id := artifact.State("kms_key_id"); id != artifact.KmsKeyID {
+		t.Fatalf("Bad: kms_key_id state was %v instead of %s", id, artifact.KmsKeyID)
 	}
 
-	if !reflect.DeepEqual(result, expected) {
-		t.Fatalf("Bad: HCP registry metadata was %#v instead of %#v", result, expected)
+	if s := artifact.String(); !strings.Contains(s, artifact.KmsKeyID) {
+		t.Fatalf("Bad: artifact string %q does not mention KMS key %s", s, artifact.KmsKeyID)
 	}
 }
 
@@ -8,6 +8,7 @@ package oci
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2/hcldec"
 	ocommon "github.com/hashicorp/packer-plugin-oracle/builder/common"
@@ -39,7 +40,22 @@ func (b *Builder) Prepare(raws ...interface{}) ([]string, []string, error) {
 		return nil, nil, err
 	}
 
-	return nil, nil, nil
+	generatedData := []string{
+		"SourceImageOCID",
+		"SourceImageName",
+		"SourceImageOSVersion",
+		"InstanceOCID",
+		"CapacityReservationOCID",
+		"DedicatedVmHostOCID",
+		"AvailabilityDomain",
+		"FaultDomain",
+		"Shape",
+		"PrivateIP",
+		"PublicIP",
+		"ISCSILoginCommands",
+	}
+
+	return generatedData, nil, nil
 }
 
 func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook) (packersdk.Artifact, error) {
@@ -48,22 +64,114 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 		return nil, err
 	}
 
-	// Populate the state bag
-	state := new(multistep.BasicStateBag)
-	state.Put("config", &b.config)
-	state.Put("driver", driver)
-	state.Put("hook", hook)
-	state.Put("ui", ui)
+	// Steps point the communicator, VNIC and placement settings at resources
+	// of the current attempt, so every attempt starts from the configured
+	// values.
+	config := b.config
+
+	var (
+		state                *multistep.BasicStateBag
+		preservedBootVolumes []string
+	)
+	for attempt := 0; ; attempt++ {
+		b.config = config
+
+		// Populate the state bag
+		state = new(multistep.BasicStateBag)
+		state.Put("config", &b.config)
+		state.Put("driver", driver)
+		state.Put("hook", hook)
+		state.Put("ui", ui)
+
+		// Run the steps
+		b.runner = commonsteps.NewRunnerWithPauseFn(b.steps(), b.config.PackerConfig, ui, state)
+		b.runner.Run(ctx, state)
+
+		if id, ok := state.GetOk("preserved_boot_volume_id"); ok {
+			preservedBootVolumes = append(preservedBootVolumes, id.(string))
+		}
+
+		if _, preempted := state.GetOk("instance_preempted"); !preempted || ctx.Err() != nil {
+			break
+		}
+		if attempt >= b.config.PreemptibleRelaunchAttempts {
+			state.Put("error", fmt.Errorf("Instance was reclaimed by OCI %d time(s), giving up: %s", attempt+1, state.Get("error")))
+			break
+		}
+
+		ui.Say(fmt.Sprintf("Relaunching preemptible instance (attempt %d of %d)...", attempt+1, b.config.PreemptibleRelaunchAttempts))
+	}
+
+	if len(preservedBootVolumes) > 0 {
+		ui.Say(fmt.Sprintf("Reclaimed instances left these preserved boot volumes behind:\n%s", strings.Join(preservedBootVolumes, "\n")))
+	}
+
+	// If there was an error, return that
+	if rawErr, ok := state.GetOk("error"); ok {
+		return nil, rawErr.(error)
+	}
+
+	region, err := b.config.configProvider.Region()
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the artifact and return it
+	artifact := &Artifact{
+		Images:    map[string]string{},
+		KmsKeyID:  b.config.BootVolumeKmsKeyID,
+		driver:    driver,
+		StateData: map[string]interface{}{"generated_data": state.Get("generated_data")},
+	}
+
+	if image, ok := state.GetOk("image"); ok {
+		artifact.Image = image.(core.Image)
+		artifact.Images[region] = *artifact.Image.Id
+		if copies, ok := state.GetOk("image_copies"); ok {
+			for copyRegion, id := range copies.(map[string]string) {
+				artifact.Images[copyRegion] = id
+			}
+		}
+	}
+
+	if id, ok := state.GetOk("boot_volume_backup_id"); ok {
+		artifact.BootVolumeBackupID = id.(string)
+	}
+
+	if ids, ok := state.GetOk("volume_backup_ids"); ok {
+		artifact.VolumeBackupIDs = ids.([]string)
+	}
+
+	if len(artifact.Images) == 0 && artifact.BootVolumeBackupID == "" && len(artifact.VolumeBackupIDs) == 0 {
+		return nil, nil
+	}
+
+	return artifact, nil
+}
 
-	// Build the steps
-	steps := []multistep.Step{
+// steps returns the build steps. Steps keep track of the resources they
+// create, so a new set is needed for every attempt.
+func (b *Builder) steps() []multistep.Step {
+	return []multistep.Step{
+		&stepPreflightValidation{},
+		&stepFindExistingImages{
+			SkipCreateImage: b.config.SkipCreateImage || b.config.ArtifactType == "boot_volume_backup",
+		},
 		&ocommon.StepKeyPair{
 			Debug:        b.config.PackerDebug,
 			Comm:         &b.config.Comm,
 			DebugKeyPath: fmt.Sprintf("oci_%s.pem", b.config.PackerBuildName),
 		},
+		&stepCreateNetwork{},
 		&stepCreateInstance{},
+		&stepCaptureConsoleHistory{
+			Path: fmt.Sprintf("oci_%s_console.log", b.config.PackerBuildName),
+		},
+		&stepSourceImageInfo{},
+		&stepAttachVolumes{},
 		&stepInstanceInfo{},
+		&stepRenderImageTemplates{},
+		&stepCreateBastionSession{},
 		&stepGetDefaultCredentials{
 			Debug:     b.config.PackerDebug,
 			Comm:      &b.config.Comm,
@@ -73,44 +181,35 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 			Config:    &b.config.Comm,
 			Host:      communicator.CommHost(b.config.Comm.Host(), "instance_ip"),
 			SSHConfig: b.config.Comm.SSHConfigFunc(),
+			CustomConnect: map[string]multistep.Step{
+				"run_command": &stepConnectRunCommand{},
+			},
+		},
+		&stepChangeWindowsPassword{
+			Debug: b.config.PackerDebug,
+			Comm:  &b.config.Comm,
+			Host:  communicator.CommHost(b.config.Comm.Host(), "instance_ip"),
 		},
 		&commonsteps.StepProvision{},
 		&commonsteps.StepCleanupTempKeys{
 			Comm: &b.config.Comm,
 		},
 		&stepImage{
-			SkipCreateImage: b.config.SkipCreateImage,
+			SkipCreateImage: b.config.SkipCreateImage || b.config.ArtifactType == "boot_volume_backup",
+		},
+		&stepCopyImage{
+			Regions: b.config.CopyToRegions,
+		},
+		&stepBootVolumeBackup{
+			Skip: b.config.ArtifactType == "image",
+		},
+		&stepBackupVolumes{},
+		// Older images are only removed once everything else succeeded.
+		&stepDeleteExistingImages{},
+		&stepImageRetention{
+			SkipCreateImage: b.config.SkipCreateImage || b.config.ArtifactType == "boot_volume_backup",
 		},
 	}
-
-	// Run the steps
-	b.runner = commonsteps.NewRunnerWithPauseFn(steps, b.config.PackerConfig, ui, state)
-	b.runner.Run(ctx, state)
-
-	// If there was an error, return that
-	if rawErr, ok := state.GetOk("error"); ok {
-		return nil, rawErr.(error)
-	}
-
-	region, err := b.config.configProvider.Region()
-	if err != nil {
-		return nil, err
-	}
-
-	image, ok := state.GetOk("image")
-	if !ok {
-		return nil, err
-	}
-
-	// Build the artifact and return it
-	artifact := &Artifact{
-		Image:     image.(core.Image),
-		Region:    region,
-		driver:    driver,
-		StateData: map[string]interface{}{"generated_data": state.Get("generated_data")},
-	}
-
-	return artifact, nil
 }
 
 // Cancel terminates a running build.
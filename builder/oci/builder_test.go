@@ -16,3 +16,33 @@ func TestBuilder_ImplementsBuilder(t *testing.T) {
 		t.Fatalf("Builder should be a builder")
 	}
 }
+
+func TestBuilder_DestructiveStepsLast(t *testing.T) {
+	steps := (&Builder{}).steps()
+
+	if _, ok := steps[len(steps)-2].(*stepDeleteExistingImages); !ok {
+		t.Fatalf("existing images should be deleted after every other step, got %T", steps[len(steps)-2])
+	}
+	if _, ok := steps[len(steps)-1].(*stepImageRetention); !ok {
+		t.Fatalf("image retention should be applied last, got %T", steps[len(steps)-1])
+	}
+}
+
+func TestBuilder_SkipCreateImageKeepsBootVolumeBackup(t *testing.T) {
+	b := &Builder{}
+	b.config.SkipCreateImage = true
+	b.config.ArtifactType = "both"
+
+	for _, step := range b.steps() {
+		switch step := step.(type) {
+		case *stepImage:
+			if !step.SkipCreateImage {
+				t.Fatalf("should skip the image")
+			}
+		case *stepBootVolumeBackup:
+			if step.Skip {
+				t.Fatalf("should still back up the boot volume")
+			}
+		}
+	}
+}
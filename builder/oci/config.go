@@ -1,7 +1,7 @@
 // Copyright IBM Corp. 2013, 2025
 // SPDX-License-Identifier: MPL-2.0
 
-//go:generate packer-sdc mapstructure-to-hcl2 -type Config,CreateVNICDetails,ListImagesRequest,FlexShapeConfig
+//go:generate packer-sdc mapstructure-to-hcl2 -type Config,AgentConfig,AgentPluginConfig,AvailabilityConfig,ImageRetention,CreateVNICDetails,ListImagesRequest,FlexShapeConfig,FallbackShape,LaunchOptions,PlatformConfig,PreemptibleInstanceConfig,BlockVolume
 
 package oci
 
@@ -12,19 +12,19 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net"
 	"os"
-	"os/user"
-	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/packer-plugin-sdk/common"
 	"github.com/hashicorp/packer-plugin-sdk/communicator"
 	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
-	"github.com/hashicorp/packer-plugin-sdk/pathing"
 	"github.com/hashicorp/packer-plugin-sdk/template/config"
 	"github.com/hashicorp/packer-plugin-sdk/template/interpolate"
 	ocicommon "github.com/oracle/oci-go-sdk/v65/common"
-	ociauth "github.com/oracle/oci-go-sdk/v65/common/auth"
 )
 
 type CreateVNICDetails struct {
@@ -45,6 +45,131 @@ type CreateVNICDetails struct {
 	SubnetId            *string                           `mapstructure:"subnet_id" required:"false"`
 }
 
+type AgentConfig struct {
+	// fields that can be specified under "agent_config"
+	IsMonitoringDisabled  *bool               `mapstructure:"is_monitoring_disabled" required:"false"`
+	IsManagementDisabled  *bool               `mapstructure:"is_management_disabled" required:"false"`
+	AreAllPluginsDisabled *bool               `mapstructure:"are_all_plugins_disabled" required:"false"`
+	PluginsConfig         []AgentPluginConfig `mapstructure:"plugins_config" required:"false"`
+}
+
+type AgentPluginConfig struct {
+	// fields that can be specified under "plugins_config"
+	Name         string `mapstructure:"name" required:"true"`
+	DesiredState string `mapstructure:"desired_state" required:"true"`
+}
+
+// agentPlugins are the Oracle Cloud Agent plugins that can be configured
+// when launching an instance.
+var agentPlugins = []string{
+	"Bastion",
+	"Block Volume Management",
+	"Cloud Guard Workload Protection",
+	"Compute HPC RDMA Auto-Configuration",
+	"Compute HPC RDMA Authentication",
+	"Compute Instance Monitoring",
+	"Compute Instance Run Command",
+	"Compute RDMA GPU Monitoring",
+	"Custom Logs Monitoring",
+	"Management Agent",
+	"OS Management Hub Agent",
+	"OS Management Service Agent",
+	"Oracle Autonomous Linux",
+	"Oracle Java Management Service",
+	"Vulnerability Scanning",
+}
+
+// Prepare validates the plugin names and desired states.
+func (c *AgentConfig) Prepare() []error {
+	var errs []error
+
+	seen := make(map[string]bool)
+	for i, plugin := range c.PluginsConfig {
+		if !slices.Contains(agentPlugins, plugin.Name) {
+			errs = append(errs, fmt.Errorf("'agent_config.plugins_config[%d].name' %q must be one of %s", i, plugin.Name, strings.Join(agentPlugins, ", ")))
+		} else if seen[plugin.Name] {
+			errs = append(errs, fmt.Errorf("'agent_config.plugins_config' contains plugin %q more than once", plugin.Name))
+		}
+		seen[plugin.Name] = true
+
+		if plugin.DesiredState != "ENABLED" && plugin.DesiredState != "DISABLED" {
+			errs = append(errs, fmt.Errorf("'agent_config.plugins_config[%d].desired_state' must be one of ENABLED or DISABLED", i))
+		}
+	}
+
+	return errs
+}
+
+// pluginState returns the desired state configured for a plugin, if any.
+func (c *AgentConfig) pluginState(name string) string {
+	for _, plugin := range c.PluginsConfig {
+		if plugin.Name == name {
+			return plugin.DesiredState
+		}
+	}
+	return ""
+}
+
+type AvailabilityConfig struct {
+	// fields that can be specified under "availability_config"
+	IsLiveMigrationPreferred *bool  `mapstructure:"is_live_migration_preferred" required:"false"`
+	RecoveryAction           string `mapstructure:"recovery_action" required:"false"`
+}
+
+type ImageRetention struct {
+	// fields that can be specified under "image_retention"
+	DisplayNamePattern string            `mapstructure:"display_name_pattern" required:"false"`
+	Tags               map[string]string `mapstructure:"tags" required:"false"`
+	Keep               int               `mapstructure:"keep" required:"true"`
+	Action             string            `mapstructure:"action" required:"false"`
+	DryRun             bool              `mapstructure:"dry_run" required:"false"`
+
+	displayNameRe *regexp.Regexp
+}
+
+// Prepare validates the retention policy and sets defaults.
+func (c *ImageRetention) Prepare() []error {
+	var errs []error
+
+	if c.DisplayNamePattern == "" && len(c.Tags) == 0 {
+		errs = append(errs, errors.New("'image_retention' requires 'display_name_pattern' or 'tags' to select images"))
+	}
+	if c.DisplayNamePattern != "" {
+		re, err := regexp.Compile(c.DisplayNamePattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'image_retention.display_name_pattern' is invalid: %s", err))
+		}
+		c.displayNameRe = re
+	}
+
+	if c.Keep < 1 {
+		errs = append(errs, errors.New("'image_retention.keep' must be at least 1"))
+	}
+
+	if c.Action == "" {
+		c.Action = "delete"
+	}
+	if c.Action != "delete" && c.Action != "deprecate" {
+		errs = append(errs, errors.New("'image_retention.action' must be one of delete or deprecate"))
+	}
+
+	return errs
+}
+
+// Matches reports whether an image belongs to the lineage selected by the
+// retention policy.
+func (c *ImageRetention) Matches(displayName string, tags map[string]string) bool {
+	if c.displayNameRe != nil && !c.displayNameRe.MatchString(displayName) {
+		return false
+	}
+	for key, value := range c.Tags {
+		if tags[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
 type ListImagesRequest struct {
 	// fields that can be specified under "base_image_filter"
 	CompartmentId          *string `mapstructure:"compartment_id"`
@@ -61,44 +186,140 @@ type FlexShapeConfig struct {
 	BaselineOcpuUtilization *string  `mapstructure:"baseline_ocpu_utilization" required:"false"`
 }
 
+type FallbackShape struct {
+	// fields that can be specified under "fallback_shapes"
+	Shape       string          `mapstructure:"shape" required:"true"`
+	ShapeConfig FlexShapeConfig `mapstructure:"shape_config" required:"false"`
+}
+
+type LaunchOptions struct {
+	// fields that can be specified under "launch_options"
+	Firmware                        string `mapstructure:"firmware" required:"false"`
+	BootVolumeType                  string `mapstructure:"boot_volume_type" required:"false"`
+	RemoteDataVolumeType            string `mapstructure:"remote_data_volume_type" required:"false"`
+	NetworkType                     string `mapstructure:"network_type" required:"false"`
+	IsConsistentVolumeNamingEnabled *bool  `mapstructure:"is_consistent_volume_naming_enabled" required:"false"`
+	IsPvEncryptionInTransitEnabled  *bool  `mapstructure:"is_pv_encryption_in_transit_enabled" required:"false"`
+}
+
+var volumeTypes = []string{"ISCSI", "SCSI", "IDE", "VFIO", "PARAVIRTUALIZED"}
+
+// Prepare validates the launch options.
+func (c *LaunchOptions) Prepare() []error {
+	var errs []error
+
+	if c.Firmware != "" && c.Firmware != "BIOS" && c.Firmware != "UEFI_64" {
+		errs = append(errs, errors.New("'launch_options.firmware' must be one of BIOS or UEFI_64"))
+	}
+	if c.BootVolumeType != "" && !slices.Contains(volumeTypes, c.BootVolumeType) {
+		errs = append(errs, errors.New("'launch_options.boot_volume_type' must be one of ISCSI, SCSI, IDE, VFIO, or PARAVIRTUALIZED"))
+	}
+	if c.RemoteDataVolumeType != "" && !slices.Contains(volumeTypes, c.RemoteDataVolumeType) {
+		errs = append(errs, errors.New("'launch_options.remote_data_volume_type' must be one of ISCSI, SCSI, IDE, VFIO, or PARAVIRTUALIZED"))
+	}
+	if c.NetworkType != "" && c.NetworkType != "E1000" && c.NetworkType != "VFIO" && c.NetworkType != "PARAVIRTUALIZED" {
+		errs = append(errs, errors.New("'launch_options.network_type' must be one of E1000, VFIO, or PARAVIRTUALIZED"))
+	}
+	// In-transit encryption only applies to paravirtualized volumes.
+	if c.IsPvEncryptionInTransitEnabled != nil && *c.IsPvEncryptionInTransitEnabled &&
+		c.BootVolumeType != "" && c.BootVolumeType != "PARAVIRTUALIZED" {
+		errs = append(errs, errors.New("'launch_options.is_pv_encryption_in_transit_enabled' requires a PARAVIRTUALIZED 'boot_volume_type'"))
+	}
+
+	return errs
+}
+
+type PlatformConfig struct {
+	// fields that can be specified under "platform_config"
+	Type                           string `mapstructure:"type" required:"true"`
+	IsSecureBootEnabled            bool   `mapstructure:"is_secure_boot_enabled" required:"false"`
+	IsMeasuredBootEnabled          bool   `mapstructure:"is_measured_boot_enabled" required:"false"`
+	IsTrustedPlatformModuleEnabled bool   `mapstructure:"is_trusted_platform_module_enabled" required:"false"`
+	IsMemoryEncryptionEnabled      bool   `mapstructure:"is_memory_encryption_enabled" required:"false"`
+}
+
+var (
+	amdShapeRe = regexp.MustCompile(`^(VM|BM)\.[A-Za-z]+\.E\d+\.`)
+	armShapeRe = regexp.MustCompile(`^(VM|BM)\.[A-Za-z]+\.A\d+\.`)
+)
+
+// Prepare validates the platform configuration against the shape family of
+// the instance.
+func (c *PlatformConfig) Prepare(shape string) []error {
+	var errs []error
+
+	var vm, amd bool
+	switch c.Type {
+	case "AMD_VM":
+		vm, amd = true, true
+	case "INTEL_VM":
+		vm = true
+	case "AMD_MILAN_BM", "AMD_ROME_BM", "AMD_ROME_BM_GPU":
+		amd = true
+	case "INTEL_ICELAKE_BM", "INTEL_SKYLAKE_BM":
+	default:
+		return append(errs, errors.New("'platform_config.type' must be one of AMD_VM, INTEL_VM, AMD_MILAN_BM, AMD_ROME_BM, AMD_ROME_BM_GPU, INTEL_ICELAKE_BM, or INTEL_SKYLAKE_BM"))
+	}
+
+	switch {
+	case vm && !strings.HasPrefix(shape, "VM."), !vm && !strings.HasPrefix(shape, "BM."):
+		errs = append(errs, fmt.Errorf("'platform_config.type' %s can't be used with shape %s", c.Type, shape))
+	case c.Type == "AMD_ROME_BM_GPU" && !strings.HasPrefix(shape, "BM.GPU"):
+		errs = append(errs, fmt.Errorf("'platform_config.type' %s can only be used with GPU shapes", c.Type))
+	case c.Type != "AMD_ROME_BM_GPU" && amd != amdShapeRe.MatchString(shape), armShapeRe.MatchString(shape):
+		errs = append(errs, fmt.Errorf("'platform_config.type' %s doesn't match the processor of shape %s", c.Type, shape))
+	}
+
+	if c.IsMeasuredBootEnabled && !c.IsTrustedPlatformModuleEnabled {
+		errs = append(errs, errors.New("'platform_config.is_measured_boot_enabled' requires 'is_trusted_platform_module_enabled'"))
+	}
+
+	if c.IsMemoryEncryptionEnabled {
+		if !amd || c.Type == "AMD_ROME_BM_GPU" {
+			errs = append(errs, fmt.Errorf("'platform_config.is_memory_encryption_enabled' isn't supported by type %s", c.Type))
+		}
+		// Confidential instances can't be shielded instances as well.
+		if c.IsSecureBootEnabled || c.IsMeasuredBootEnabled || c.IsTrustedPlatformModuleEnabled {
+			errs = append(errs, errors.New("'platform_config.is_memory_encryption_enabled' can't be combined with secure boot, measured boot or TPM"))
+		}
+	}
+
+	return errs
+}
+
+type PreemptibleInstanceConfig struct {
+	// fields that can be specified under "preemptible_instance_config"
+	PreserveBootVolume bool `mapstructure:"preserve_boot_volume" required:"false"`
+}
+
+type BlockVolume struct {
+	// fields that can be specified under "block_volumes"
+	DisplayName    string `mapstructure:"display_name" required:"false"`
+	SizeInGBs      int64  `mapstructure:"size_in_gbs" required:"false"`
+	VpusPerGB      *int64 `mapstructure:"vpus_per_gb" required:"false"`
+	AttachmentType string `mapstructure:"attachment_type" required:"false"`
+	Device         string `mapstructure:"device" required:"false"`
+	KeepBackup     bool   `mapstructure:"keep_backup" required:"false"`
+}
+
 type Config struct {
 	common.PackerConfig `mapstructure:",squash"`
 	Comm                communicator.Config `mapstructure:",squash"`
 
-	configProvider ocicommon.ConfigurationProvider
-
-	// Instance Principals (OPTIONAL)
-	// If set to true the following can't have non empty values
-	// - AccessCfgFile
-	// - AccessCfgFileAccount
-	// - UserID
-	// - TenancyID
-	// - Region
-	// - Fingerprint
-	// - KeyFile
-	// - PassPhrase
-	InstancePrincipals bool `mapstructure:"use_instance_principals"`
+	AccessConfig `mapstructure:",squash"`
 
 	// If true, Packer will not create the image. Useful for setting to `true`
 	// during a build test stage. Default `false`.
 	SkipCreateImage bool `mapstructure:"skip_create_image" required:"false"`
 
-	AccessCfgFile        string `mapstructure:"access_cfg_file"`
-	AccessCfgFileAccount string `mapstructure:"access_cfg_file_account"`
+	UsePrivateIP       bool   `mapstructure:"use_private_ip"`
+	AvailabilityDomain string `mapstructure:"availability_domain"`
+	CompartmentID      string `mapstructure:"compartment_ocid"`
 
-	// Access config overrides
-	UserID       string `mapstructure:"user_ocid"`
-	TenancyID    string `mapstructure:"tenancy_ocid"`
-	Region       string `mapstructure:"region"`
-	Fingerprint  string `mapstructure:"fingerprint"`
-	Key          string `mapstructure:"key"`
-	KeyFile      string `mapstructure:"key_file"`
-	PassPhrase   string `mapstructure:"pass_phrase"`
-	UsePrivateIP bool   `mapstructure:"use_private_ip"`
-
-	SecurityTokenFilePath string `mapstructure:"security_token_file"`
-	AvailabilityDomain    string `mapstructure:"availability_domain"`
-	CompartmentID         string `mapstructure:"compartment_ocid"`
+	// ChangeInitialPassword changes the Windows password after connecting
+	// over WinRM, to NewWinRMPassword or a random password.
+	ChangeInitialPassword bool   `mapstructure:"change_initial_password" required:"false"`
+	NewWinRMPassword      string `mapstructure:"new_winrm_password" required:"false"`
 
 	// Image
 	BaseImageID        string            `mapstructure:"base_image_ocid"`
@@ -108,6 +329,25 @@ type Config struct {
 	LaunchMode         string            `mapstructure:"image_launch_mode"`
 	NicAttachmentType  string            `mapstructure:"nic_attachment_type"`
 
+	// ArtifactType selects what the build produces: a custom "image", a
+	// "boot_volume_backup" of the instance's boot volume, or "both".
+	ArtifactType         string `mapstructure:"artifact_type"`
+	BootVolumeBackupType string `mapstructure:"boot_volume_backup_type"`
+
+	// ImageRetention deletes or deprecates older images of the same lineage
+	// once the build succeeded, in every region the image lives in.
+	ImageRetention *ImageRetention `mapstructure:"image_retention" required:"false"`
+
+	// ForceDeleteExistingImage deletes the images already named ImageName
+	// once the build succeeded.
+	ForceDeleteExistingImage bool `mapstructure:"force_delete_existing_image" required:"false"`
+
+	// Image copies
+	// The image is exported to CopyImageBucketName in the build region and
+	// imported from there into every region listed in CopyToRegions.
+	CopyToRegions       []string `mapstructure:"copy_to_regions"`
+	CopyImageBucketName string   `mapstructure:"copy_image_bucket_name"`
+
 	// Instance
 	InstanceName *string           `mapstructure:"instance_name"`
 	InstanceTags map[string]string `mapstructure:"instance_tags"`
@@ -119,8 +359,72 @@ type Config struct {
 	Shape                                         string                            `mapstructure:"shape"`
 	ShapeConfig                                   FlexShapeConfig                   `mapstructure:"shape_config"`
 	BootVolumeSizeInGBs                           int64                             `mapstructure:"disk_size"`
+	BootVolumeKmsKeyID                            string                            `mapstructure:"boot_volume_kms_key_ocid" required:"false"`
+	BootVolumeVpusPerGB                           *int64                            `mapstructure:"boot_volume_vpus_per_gb" required:"false"`
 	InstanceOptionsAreLegacyImdsEndpointsDisabled *bool                             `mapstructure:"instance_options_are_legacy_imds_endpoints_disabled" required:"false"`
 
+	// Capacity fallback
+	// When OCI is out of capacity, the instance is launched in the next
+	// fault domain, then the next availability domain, then with the next
+	// shape. AvailabilityDomains and FallbackShapes are tried after
+	// AvailabilityDomain and Shape.
+	AvailabilityDomains []string        `mapstructure:"availability_domains" required:"false"`
+	FaultDomains        []string        `mapstructure:"fault_domains" required:"false"`
+	FallbackShapes      []FallbackShape `mapstructure:"fallback_shapes" required:"false"`
+
+	// PreflightValidation checks the compartment, network, shape and base
+	// image against OCI before anything is created.
+	PreflightValidation bool `mapstructure:"preflight_validation" required:"false"`
+
+	// Timeouts
+	// How long to wait for the instance to be running, for the image to be
+	// available and for the instance to be terminated. ImageCreateTimeout also
+	// bounds image exports, imports and volume backups, ResourceTimeout the
+	// temporary network, block volumes, bastion sessions and console history
+	// captures.
+	InstanceLaunchTimeout    time.Duration `mapstructure:"instance_launch_timeout" required:"false"`
+	ImageCreateTimeout       time.Duration `mapstructure:"image_create_timeout" required:"false"`
+	InstanceTerminateTimeout time.Duration `mapstructure:"instance_terminate_timeout" required:"false"`
+	ResourceTimeout          time.Duration `mapstructure:"resource_timeout" required:"false"`
+
+	// faultDomain is the fault domain the instance is launched in, if any.
+	faultDomain string
+
+	// RunCommandBucketName is the Object Storage bucket files are staged in
+	// by the "run_command" communicator, which runs commands through the
+	// Run Command plugin of the Oracle Cloud Agent. RunCommandTimeout limits
+	// how long a single command can run.
+	RunCommandBucketName string        `mapstructure:"run_command_bucket_name" required:"false"`
+	RunCommandTimeout    time.Duration `mapstructure:"run_command_timeout" required:"false"`
+
+	// AgentConfig configures the Oracle Cloud Agent of the instance, and
+	// AvailabilityConfig its live migration and recovery behavior.
+	AgentConfig        *AgentConfig        `mapstructure:"agent_config" required:"false"`
+	AvailabilityConfig *AvailabilityConfig `mapstructure:"availability_config" required:"false"`
+
+	// LaunchOptions tune the compatibility and performance of the build
+	// instance and become the defaults of the image.
+	LaunchOptions *LaunchOptions `mapstructure:"launch_options" required:"false"`
+
+	// PlatformConfig enables shielded (Secure Boot, Measured Boot, TPM) or
+	// confidential (memory encryption) instance features.
+	PlatformConfig *PlatformConfig `mapstructure:"platform_config" required:"false"`
+
+	// PreemptibleInstanceConfig launches the instance on preemptible
+	// capacity. If the instance is reclaimed during the build, the build is
+	// restarted up to PreemptibleRelaunchAttempts times.
+	PreemptibleInstanceConfig   *PreemptibleInstanceConfig `mapstructure:"preemptible_instance_config" required:"false"`
+	PreemptibleRelaunchAttempts int                        `mapstructure:"preemptible_relaunch_attempts" required:"false"`
+
+	// Placement on reserved capacity. Neither can be combined with
+	// preemptible capacity.
+	CapacityReservationID string `mapstructure:"capacity_reservation_ocid" required:"false"`
+	DedicatedVmHostID     string `mapstructure:"dedicated_vm_host_ocid" required:"false"`
+
+	// BlockVolumes are created and attached to the instance before
+	// provisioning, then detached and deleted once the build is done.
+	BlockVolumes []BlockVolume `mapstructure:"block_volumes" required:"false"`
+
 	// Metadata optionally contains custom metadata key/value pairs provided in the
 	// configuration. While this can be used to set metadata["user_data"] the explicit
 	// "user_data" and "user_data_file" values will have precedence.
@@ -136,6 +440,19 @@ type Config struct {
 	SubnetID          string            `mapstructure:"subnet_ocid"`
 	CreateVnicDetails CreateVNICDetails `mapstructure:"create_vnic_details"`
 
+	// Temporary networking, created when no subnet is specified and deleted
+	// once the build is done.
+	TemporaryVcnCidrBlock       string   `mapstructure:"temporary_vcn_cidr_block"`
+	TemporaryNetworkSourceCidrs []string `mapstructure:"temporary_network_source_cidrs"`
+
+	// Bastion
+	// When BastionID is set the communicator reaches the instance's private
+	// IP through a session on that OCI Bastion, created once the instance is
+	// running and deleted at the end of the build.
+	BastionID          string        `mapstructure:"bastion_ocid"`
+	BastionSessionType string        `mapstructure:"bastion_session_type"`
+	BastionSessionTTL  time.Duration `mapstructure:"bastion_session_ttl"`
+
 	// Tagging
 	Tags map[string]string `mapstructure:"tags"`
 	// HCL cannot be decoded into an interface so for HCL templates you must use the DefinedTagsJson option,
@@ -148,25 +465,38 @@ type Config struct {
 	ctx interpolate.Context
 }
 
-func (c *Config) ConfigProvider() ocicommon.ConfigurationProvider {
-	return c.configProvider
-}
-
 func (c *Config) Prepare(raws ...interface{}) error {
 
 	// Decode from template
 	err := config.Decode(c, &config.DecodeOpts{
 		Interpolate:        true,
 		InterpolateContext: &c.ctx,
+		InterpolateFilter: &interpolate.RenderFilter{
+			// Rendered once the instance is running, as they can use
+			// generated data.
+			Exclude: []string{
+				"image_name",
+				"tags",
+			},
+		},
 	}, raws...)
 	if err != nil {
 		return fmt.Errorf("Failed to mapstructure Config: %+v", err)
 	}
 
 	var errs *packersdk.MultiError
+	// "run_command" is provided by this builder rather than the SDK, which
+	// has nothing to prepare for it.
+	runCommand := c.Comm.Type == "run_command"
+	if runCommand {
+		c.Comm.Type = "none"
+	}
 	if es := c.Comm.Prepare(&c.ctx); len(es) > 0 {
 		errs = packersdk.MultiErrorAppend(errs, es...)
 	}
+	if runCommand {
+		c.Comm.Type = "run_command"
+	}
 
 	if c.InstanceDefinedTagsJson != "" {
 		if err := json.Unmarshal([]byte(c.InstanceDefinedTagsJson), &c.InstanceDefinedTags); err != nil {
@@ -186,141 +516,13 @@ func (c *Config) Prepare(raws ...interface{}) error {
 		}
 	}
 
-	var tenancyOCID string
-
-	if c.InstancePrincipals {
-		// We could go through all keys in one go and report that the below set
-		// of keys cannot coexist with use_instance_principals but decided to
-		// split them and report them seperately so that the user sees the specific
-		// key involved.
-		var message string = " cannot be present when use_instance_principals is set to true."
-		if c.AccessCfgFile != "" {
-			errs = packersdk.MultiErrorAppend(errs, errors.New("access_cfg_file"+message))
-		}
-		if c.AccessCfgFileAccount != "" {
-			errs = packersdk.MultiErrorAppend(errs, errors.New("access_cfg_file_account"+message))
-		}
-		if c.UserID != "" {
-			errs = packersdk.MultiErrorAppend(errs, errors.New("user_ocid"+message))
-		}
-		if c.TenancyID != "" {
-			errs = packersdk.MultiErrorAppend(errs, errors.New("tenancy_ocid"+message))
-		}
-		if c.Region != "" {
-			errs = packersdk.MultiErrorAppend(errs, errors.New("region"+message))
-		}
-		if c.Fingerprint != "" {
-			errs = packersdk.MultiErrorAppend(errs, errors.New("fingerprint"+message))
-		}
-		if c.Key != "" {
-			errs = packersdk.MultiErrorAppend(errs, errors.New("key"+message))
-		}
-		if c.KeyFile != "" {
-			errs = packersdk.MultiErrorAppend(errs, errors.New("key_file"+message))
-		}
-		if c.PassPhrase != "" {
-			errs = packersdk.MultiErrorAppend(errs, errors.New("pass_phrase"+message))
-		}
-		// This check is used to facilitate testing. During testing a Mock struct
-		// is assigned to c.configProvider otherwise testing fails because Instance
-		// Principals cannot be obtained.
-		if c.configProvider == nil {
-			// Even though the previous configuration checks might fail we don't want
-			// to skip this step. It seems that the logic behind the checks in this
-			// file is to check everything even getting the configProvider.
-			c.configProvider, err = ociauth.InstancePrincipalConfigurationProvider()
-			if err != nil {
-				return err
-			}
-		}
-		tenancyOCID, err = c.configProvider.TenancyOCID()
-		if err != nil {
-			return err
-		}
-	} else {
-		// Determine where the SDK config is located
-		if c.AccessCfgFile == "" {
-			c.AccessCfgFile, err = getDefaultOCISettingsPath()
-			if err != nil {
-				log.Println("Default OCI settings file not found")
-			}
-		}
-
-		if c.AccessCfgFileAccount == "" {
-			c.AccessCfgFileAccount = "DEFAULT"
-		}
-
-		// Read API signing key
-		var keyContent []byte
-		if c.KeyFile != "" {
-			path, err := pathing.ExpandUser(c.KeyFile)
-			if err != nil {
-				return err
-			}
-
-			// Read API signing key
-			keyContent, err = ioutil.ReadFile(path)
-			if err != nil {
-				return err
-			}
-		}
-		if c.Key != "" {
-			keyContent = []byte(c.Key)
-		}
-
-		// Providers
-		fileProvider, _ := ocicommon.ConfigurationProviderFromFileWithProfile(c.AccessCfgFile, c.AccessCfgFileAccount, c.PassPhrase)
-		if c.Region == "" {
-			var region string
-			if fileProvider != nil {
-				region, _ = fileProvider.Region()
-			}
-			if region == "" {
-				c.Region = "us-phoenix-1"
-			}
-		}
-
-		providers := []ocicommon.ConfigurationProvider{
-			ocicommon.NewRawConfigurationProvider(c.TenancyID, c.UserID, c.Region, c.Fingerprint, string(keyContent), &c.PassPhrase),
-		}
-
-		if fileProvider != nil {
-			providers = append(providers, fileProvider)
-		}
-
-		// Load API access configuration from SDK
-		configProvider, err := ocicommon.ComposingConfigurationProvider(providers)
-		if err != nil {
-			return err
-		}
-
-		tenancyOCID, _ = configProvider.TenancyOCID()
-		if tenancyOCID == "" {
-			errs = packersdk.MultiErrorAppend(
-				errs, errors.New("'tenancy_ocid' must be specified"))
-		}
-
-		if fingerprint, _ := configProvider.KeyFingerprint(); fingerprint == "" {
-			errs = packersdk.MultiErrorAppend(
-				errs, errors.New("'fingerprint' must be specified"))
-		}
-
-		if _, err := configProvider.UserOCID(); err != nil {
-			errs = packersdk.MultiErrorAppend(
-				errs, fmt.Errorf("'user_ocid' must be correctly specified. %w", err))
-		}
-
-		if _, err := configProvider.KeyID(); err != nil {
-			errs = packersdk.MultiErrorAppend(
-				errs, fmt.Errorf("'security_token_file' must be correctly specified. %w", err))
-		}
-
-		if _, err := configProvider.PrivateRSAKey(); err != nil {
-			errs = packersdk.MultiErrorAppend(
-				errs, fmt.Errorf("'key_file' must be correctly specified. %w", err))
-		}
+	if es := c.AccessConfig.Prepare(); len(es) > 0 {
+		errs = packersdk.MultiErrorAppend(errs, es...)
+	}
 
-		c.configProvider = configProvider
+	var tenancyOCID string
+	if c.configProvider != nil {
+		tenancyOCID, _ = c.configProvider.TenancyOCID()
 	}
 
 	if c.AvailabilityDomain == "" {
@@ -358,13 +560,64 @@ func (c *Config) Prepare(raws ...interface{}) error {
 			errs, errors.New("'Ocpus' must be specified if baseline_ocpu_utilization is specified"))
 	}
 
+	for i, fallback := range c.FallbackShapes {
+		if fallback.Shape == "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'fallback_shapes[%d].shape' must be specified", i))
+		}
+		if strings.HasSuffix(fallback.Shape, "Flex") && fallback.ShapeConfig.Ocpus == nil {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'fallback_shapes[%d].shape_config.ocpus' must be specified when using flexible shapes", i))
+		}
+		if (fallback.ShapeConfig.MemoryInGBs != nil || fallback.ShapeConfig.BaselineOcpuUtilization != nil) && fallback.ShapeConfig.Ocpus == nil {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'fallback_shapes[%d].shape_config.ocpus' must be specified if memory_in_gbs or baseline_ocpu_utilization is specified", i))
+		}
+	}
+
+	for _, ad := range c.AvailabilityDomains {
+		if ad == "" || ad == c.AvailabilityDomain {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'availability_domains' must not contain empty values or 'availability_domain' %q", ad))
+			break
+		}
+	}
+	for _, fd := range c.FaultDomains {
+		if fd == "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'fault_domains' must not contain empty values"))
+			break
+		}
+	}
+
 	if (c.SubnetID == "") && (c.CreateVnicDetails.SubnetId == nil) {
+		// A temporary VCN and subnet will be created for the build.
+		if c.TemporaryVcnCidrBlock == "" {
+			c.TemporaryVcnCidrBlock = "10.0.0.0/16"
+		}
+		if _, _, err := net.ParseCIDR(c.TemporaryVcnCidrBlock); err != nil {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'temporary_vcn_cidr_block' must be a valid CIDR block: %s", err))
+		}
+
+		if len(c.TemporaryNetworkSourceCidrs) == 0 {
+			c.TemporaryNetworkSourceCidrs = []string{"0.0.0.0/0"}
+		}
+		for _, cidr := range c.TemporaryNetworkSourceCidrs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				errs = packersdk.MultiErrorAppend(
+					errs, fmt.Errorf("'temporary_network_source_cidrs' must only contain valid CIDR blocks: %s", err))
+			}
+		}
+	} else if c.TemporaryVcnCidrBlock != "" || len(c.TemporaryNetworkSourceCidrs) > 0 {
 		errs = packersdk.MultiErrorAppend(
-			errs, errors.New("'subnet_ocid' must be specified"))
+			errs, errors.New("'temporary_vcn_cidr_block' and 'temporary_network_source_cidrs' can only be used when no subnet is specified"))
 	}
 
 	if c.CreateVnicDetails.SubnetId == nil {
-		c.CreateVnicDetails.SubnetId = &c.SubnetID
+		if c.SubnetID != "" {
+			c.CreateVnicDetails.SubnetId = &c.SubnetID
+		}
 	} else if (*c.CreateVnicDetails.SubnetId != c.SubnetID) && (c.SubnetID != "") {
 		errs = packersdk.MultiErrorAppend(
 			errs, errors.New("'create_vnic_details[subnet]' must match 'subnet_ocid' if both are specified"))
@@ -383,28 +636,9 @@ func (c *Config) Prepare(raws ...interface{}) error {
 		c.BaseImageFilter.Shape = &c.Shape
 	}
 
-	// Validate tag lengths. TODO (hlowndes) maximum number of tags allowed.
-	if c.Tags != nil {
-		for k, v := range c.Tags {
-			k = strings.TrimSpace(k)
-			v = strings.TrimSpace(v)
-			if len(k) > 100 {
-				errs = packersdk.MultiErrorAppend(
-					errs, fmt.Errorf("Tag key length too long. Maximum 100 but found %d. Key: %s", len(k), k))
-			}
-			if len(k) == 0 {
-				errs = packersdk.MultiErrorAppend(
-					errs, errors.New("Tag key empty in config"))
-			}
-			if len(v) > 100 {
-				errs = packersdk.MultiErrorAppend(
-					errs, fmt.Errorf("Tag value length too long. Maximum 100 but found %d. Key: %s", len(v), k))
-			}
-			if len(v) == 0 {
-				errs = packersdk.MultiErrorAppend(
-					errs, errors.New("Tag value empty in config"))
-			}
-		}
+	// Tag values that are templates are validated once rendered.
+	for _, err := range validateTags(c.Tags) {
+		errs = packersdk.MultiErrorAppend(errs, err)
 	}
 
 	if c.ImageName == "" {
@@ -453,6 +687,252 @@ func (c *Config) Prepare(raws ...interface{}) error {
 			errs, errors.New("NicAttachmentType must be one of VFIO, E1000, or PARAVIRTUALIZED"))
 	}
 
+	if c.ImageRetention != nil {
+		for _, err := range c.ImageRetention.Prepare() {
+			errs = packersdk.MultiErrorAppend(errs, err)
+		}
+	}
+
+	if c.ArtifactType == "" {
+		c.ArtifactType = "image"
+	}
+	if c.ArtifactType != "image" && c.ArtifactType != "boot_volume_backup" && c.ArtifactType != "both" {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'artifact_type' must be one of image, boot_volume_backup, or both"))
+	}
+
+	if c.BootVolumeBackupType == "" {
+		c.BootVolumeBackupType = "FULL"
+	}
+	if c.BootVolumeBackupType != "FULL" && c.BootVolumeBackupType != "INCREMENTAL" {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'boot_volume_backup_type' must be one of FULL or INCREMENTAL"))
+	}
+
+	if len(c.CopyToRegions) > 0 {
+		if c.ArtifactType == "boot_volume_backup" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'copy_to_regions' requires an 'artifact_type' that produces an image"))
+		}
+
+		if c.CopyImageBucketName == "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'copy_image_bucket_name' must be specified when 'copy_to_regions' is set"))
+		}
+
+		var buildRegion string
+		if c.configProvider != nil {
+			buildRegion, _ = c.configProvider.Region()
+		}
+		seen := make(map[string]bool)
+		for _, region := range c.CopyToRegions {
+			if region == buildRegion {
+				errs = packersdk.MultiErrorAppend(
+					errs, fmt.Errorf("'copy_to_regions' must not contain the build region %q", region))
+			} else if seen[region] {
+				errs = packersdk.MultiErrorAppend(
+					errs, fmt.Errorf("'copy_to_regions' contains duplicate region %q", region))
+			}
+			seen[region] = true
+		}
+	}
+
+	if c.PreemptibleRelaunchAttempts < 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'preemptible_relaunch_attempts' must not be negative"))
+	}
+	if c.PreemptibleRelaunchAttempts > 0 && c.PreemptibleInstanceConfig == nil {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'preemptible_relaunch_attempts' can only be used with 'preemptible_instance_config'"))
+	}
+
+	if c.LaunchOptions != nil {
+		for _, err := range c.LaunchOptions.Prepare() {
+			errs = packersdk.MultiErrorAppend(errs, err)
+		}
+		if c.LaunchOptions.NetworkType != "" && c.NicAttachmentType != "" && c.LaunchOptions.NetworkType != c.NicAttachmentType {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'launch_options.network_type' and 'nic_attachment_type' must match when both are set"))
+		}
+		if c.LaunchOptions.Firmware == "BIOS" && c.PlatformConfig != nil &&
+			(c.PlatformConfig.IsSecureBootEnabled || c.PlatformConfig.IsMeasuredBootEnabled || c.PlatformConfig.IsTrustedPlatformModuleEnabled) {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'launch_options.firmware' must be UEFI_64 for shielded instances"))
+		}
+	}
+
+	if c.PlatformConfig != nil {
+		for _, err := range c.PlatformConfig.Prepare(c.Shape) {
+			errs = packersdk.MultiErrorAppend(errs, err)
+		}
+		for _, fallback := range c.FallbackShapes {
+			for _, err := range c.PlatformConfig.Prepare(fallback.Shape) {
+				errs = packersdk.MultiErrorAppend(errs, err)
+			}
+		}
+	}
+
+	if c.CapacityReservationID != "" && c.DedicatedVmHostID != "" {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'capacity_reservation_ocid' and 'dedicated_vm_host_ocid' are mutually exclusive"))
+	}
+	if c.PreemptibleInstanceConfig != nil {
+		if c.CapacityReservationID != "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'capacity_reservation_ocid' can't be used with 'preemptible_instance_config'"))
+		}
+		if c.DedicatedVmHostID != "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'dedicated_vm_host_ocid' can't be used with 'preemptible_instance_config'"))
+		}
+	}
+
+	for i := range c.BlockVolumes {
+		volume := &c.BlockVolumes[i]
+
+		if volume.SizeInGBs == 0 {
+			volume.SizeInGBs = 50
+		}
+		if volume.SizeInGBs < 50 || volume.SizeInGBs > 32768 {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'block_volumes[%d].size_in_gbs' must be between 50 and 32768 GBs", i))
+		}
+
+		if volume.VpusPerGB != nil && (*volume.VpusPerGB < 0 || *volume.VpusPerGB > 120 || *volume.VpusPerGB%10 != 0) {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'block_volumes[%d].vpus_per_gb' must be a multiple of 10 between 0 and 120", i))
+		}
+
+		if volume.AttachmentType == "" {
+			volume.AttachmentType = "paravirtualized"
+		}
+		if volume.AttachmentType != "paravirtualized" && volume.AttachmentType != "iscsi" {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'block_volumes[%d].attachment_type' must be one of paravirtualized or iscsi", i))
+		}
+
+		if volume.Device != "" && !strings.HasPrefix(volume.Device, "/dev/oracleoci/") {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'block_volumes[%d].device' must be a consistent device path such as /dev/oracleoci/oraclevdb", i))
+		}
+	}
+
+	if c.BastionID != "" {
+		if c.BastionSessionType == "" {
+			c.BastionSessionType = "MANAGED_SSH"
+		}
+		if c.BastionSessionType != "MANAGED_SSH" && c.BastionSessionType != "PORT_FORWARDING" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'bastion_session_type' must be one of MANAGED_SSH or PORT_FORWARDING"))
+		}
+
+		if c.BastionSessionTTL == 0 {
+			c.BastionSessionTTL = 3 * time.Hour
+		}
+		if c.BastionSessionTTL < 30*time.Minute || c.BastionSessionTTL > 3*time.Hour {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'bastion_session_ttl' must be between 30m and 3h"))
+		}
+
+		if c.Comm.Type != "ssh" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'bastion_ocid' can only be used with the ssh communicator"))
+		}
+		if c.Comm.SSHBastionHost != "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'bastion_ocid' and 'ssh_bastion_host' are mutually exclusive"))
+		}
+		if !c.UsePrivateIP {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'use_private_ip' must be true when 'bastion_ocid' is set"))
+		}
+	} else if c.BastionSessionType != "" || c.BastionSessionTTL != 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'bastion_session_type' and 'bastion_session_ttl' can only be used with 'bastion_ocid'"))
+	}
+
+	if c.ChangeInitialPassword && c.Comm.Type != "winrm" {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'change_initial_password' can only be used with the winrm communicator"))
+	}
+	if c.NewWinRMPassword != "" {
+		if !c.ChangeInitialPassword {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'new_winrm_password' requires 'change_initial_password'"))
+		}
+		packersdk.LogSecretFilter.Set(c.NewWinRMPassword)
+	}
+
+	if c.Comm.Type == "run_command" {
+		if c.RunCommandBucketName == "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'run_command_bucket_name' must be specified when using the run_command communicator"))
+		}
+		if c.RunCommandTimeout == 0 {
+			c.RunCommandTimeout = time.Hour
+		}
+		if c.RunCommandTimeout < time.Second || c.RunCommandTimeout > 48*time.Hour {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'run_command_timeout' must be between 1s and 48h"))
+		}
+	} else if c.RunCommandBucketName != "" || c.RunCommandTimeout != 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'run_command_bucket_name' and 'run_command_timeout' can only be used with the run_command communicator"))
+	}
+
+	if c.InstanceLaunchTimeout == 0 {
+		c.InstanceLaunchTimeout = 30 * time.Minute
+	}
+	if c.ImageCreateTimeout == 0 {
+		c.ImageCreateTimeout = 3 * time.Hour
+	}
+	if c.InstanceTerminateTimeout == 0 {
+		c.InstanceTerminateTimeout = 30 * time.Minute
+	}
+	if c.ResourceTimeout == 0 {
+		c.ResourceTimeout = 30 * time.Minute
+	}
+	if c.InstanceLaunchTimeout < 0 || c.ImageCreateTimeout < 0 || c.InstanceTerminateTimeout < 0 || c.ResourceTimeout < 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'instance_launch_timeout', 'image_create_timeout', 'instance_terminate_timeout' and 'resource_timeout' must be positive"))
+	}
+
+	if c.AgentConfig != nil {
+		for _, err := range c.AgentConfig.Prepare() {
+			errs = packersdk.MultiErrorAppend(errs, err)
+		}
+
+		// The run_command communicator needs the Run Command plugin.
+		if c.Comm.Type == "run_command" {
+			if c.AgentConfig.AreAllPluginsDisabled != nil && *c.AgentConfig.AreAllPluginsDisabled {
+				errs = packersdk.MultiErrorAppend(
+					errs, errors.New("'agent_config.are_all_plugins_disabled' can't be used with the run_command communicator"))
+			}
+			if c.AgentConfig.pluginState("Compute Instance Run Command") == "DISABLED" {
+				errs = packersdk.MultiErrorAppend(
+					errs, errors.New("the Compute Instance Run Command plugin can't be disabled in 'agent_config' with the run_command communicator"))
+			}
+		}
+
+		// Managed SSH sessions are served by the Bastion plugin.
+		if c.BastionID != "" && c.BastionSessionType == "MANAGED_SSH" {
+			if c.AgentConfig.AreAllPluginsDisabled != nil && *c.AgentConfig.AreAllPluginsDisabled {
+				errs = packersdk.MultiErrorAppend(
+					errs, errors.New("'agent_config.are_all_plugins_disabled' can't be used with MANAGED_SSH bastion sessions"))
+			}
+			if c.AgentConfig.pluginState("Bastion") == "DISABLED" {
+				errs = packersdk.MultiErrorAppend(
+					errs, errors.New("the Bastion plugin can't be disabled in 'agent_config' with MANAGED_SSH bastion sessions"))
+			}
+		}
+	}
+
+	if c.AvailabilityConfig != nil && c.AvailabilityConfig.RecoveryAction != "" &&
+		c.AvailabilityConfig.RecoveryAction != "RESTORE_INSTANCE" && c.AvailabilityConfig.RecoveryAction != "STOP_INSTANCE" {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'availability_config.recovery_action' must be one of RESTORE_INSTANCE or STOP_INSTANCE"))
+	}
+
 	// Set default boot volume size to 50 if not set
 	// Check if size set is allowed by OCI
 	if c.BootVolumeSizeInGBs != 0 && (c.BootVolumeSizeInGBs < 50 || c.BootVolumeSizeInGBs > 16384) {
@@ -460,6 +940,26 @@ func (c *Config) Prepare(raws ...interface{}) error {
 			errs, errors.New("'disk_size' must be between 50 and 16384 GBs"))
 	}
 
+	if c.BootVolumeVpusPerGB != nil && (*c.BootVolumeVpusPerGB < 10 || *c.BootVolumeVpusPerGB > 120 || *c.BootVolumeVpusPerGB%10 != 0) {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'boot_volume_vpus_per_gb' must be a multiple of 10 between 10 and 120"))
+	}
+
+	// The key has to live in the build region, OCI rejects keys from other
+	// regions when launching the instance.
+	if c.BootVolumeKmsKeyID != "" {
+		keyRegion, ok := ocidRegion(c.BootVolumeKmsKeyID)
+		if !ok || !strings.HasPrefix(c.BootVolumeKmsKeyID, "ocid1.key.") {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'boot_volume_kms_key_ocid' %q is not a KMS key OCID", c.BootVolumeKmsKeyID))
+		} else if c.configProvider != nil {
+			if region, err := c.configProvider.Region(); err == nil && ocicommon.StringToRegion(region) != keyRegion {
+				errs = packersdk.MultiErrorAppend(
+					errs, fmt.Errorf("'boot_volume_kms_key_ocid' is in region %s, not in the build region %s", keyRegion, region))
+			}
+		}
+	}
+
 	if errs != nil && len(errs.Errors) > 0 {
 		return errs
 	}
@@ -467,22 +967,69 @@ func (c *Config) Prepare(raws ...interface{}) error {
 	return nil
 }
 
-// getDefaultOCISettingsPath uses os/user to compute the default
-// config file location ($HOME/.oci/config).
-func getDefaultOCISettingsPath() (string, error) {
-	u, err := user.Current()
+// renderImageTemplates renders image_name and the values of tags, which can
+// reference the generated data of the build.
+func (c *Config) renderImageTemplates(generatedData map[string]interface{}) error {
+	ctx := c.ctx
+	ctx.Data = generatedData
+
+	name, err := interpolate.Render(c.ImageName, &ctx)
 	if err != nil {
-		return "", err
+		return fmt.Errorf("unable to render image_name: %s", err)
 	}
+	c.ImageName = name
 
-	if u.HomeDir == "" {
-		return "", fmt.Errorf("Unable to determine the home directory for the current user.")
+	if c.Tags == nil {
+		return nil
 	}
+	tags := make(map[string]string, len(c.Tags))
+	for k, v := range c.Tags {
+		if tags[k], err = interpolate.Render(v, &ctx); err != nil {
+			return fmt.Errorf("unable to render tag %s: %s", k, err)
+		}
+	}
+	if errs := validateTags(tags); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	c.Tags = tags
+
+	return nil
+}
+
+// validateTags checks the length of freeform tags. TODO (hlowndes) maximum
+// number of tags allowed. Values that are still templates are skipped.
+func validateTags(tags map[string]string) []error {
+	var errs []error
+	for k, v := range tags {
+		k = strings.TrimSpace(k)
+		v = strings.TrimSpace(v)
+		if len(k) > 100 {
+			errs = append(errs, fmt.Errorf("Tag key length too long. Maximum 100 but found %d. Key: %s", len(k), k))
+		}
+		if len(k) == 0 {
+			errs = append(errs, errors.New("Tag key empty in config"))
+		}
+		if strings.Contains(v, "{{") {
+			continue
+		}
+		if len(v) > 100 {
+			errs = append(errs, fmt.Errorf("Tag value length too long. Maximum 100 but found %d. Key: %s", len(v), k))
+		}
+		if len(v) == 0 {
+			errs = append(errs, errors.New("Tag value empty in config"))
+		}
+	}
+	return errs
+}
 
-	path := filepath.Join(u.HomeDir, ".oci", "config")
-	if _, err := os.Stat(path); err != nil {
-		return "", err
+// ocidRegion returns the region named by a regional OCID of the form
+// ocid1.<type>.<realm>.<region>.<unique id>. Some OCIDs, such as KMS keys of
+// the form ocid1.key.<realm>.<region>.<vault id>.<unique id>, have more parts.
+func ocidRegion(ocid string) (ocicommon.Region, bool) {
+	parts := strings.Split(ocid, ".")
+	if len(parts) < 5 || parts[0] != "ocid1" || parts[3] == "" {
+		return "", false
 	}
 
-	return path, nil
+	return ocicommon.StringToRegion(parts[3]), true
 }
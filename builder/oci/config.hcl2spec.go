@@ -7,101 +7,247 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
+// FlatAgentConfig is an auto-generated flat version of AgentConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatAgentConfig struct {
+	IsMonitoringDisabled  *bool                   `mapstructure:"is_monitoring_disabled" required:"false" cty:"is_monitoring_disabled" hcl:"is_monitoring_disabled"`
+	IsManagementDisabled  *bool                   `mapstructure:"is_management_disabled" required:"false" cty:"is_management_disabled" hcl:"is_management_disabled"`
+	AreAllPluginsDisabled *bool                   `mapstructure:"are_all_plugins_disabled" required:"false" cty:"are_all_plugins_disabled" hcl:"are_all_plugins_disabled"`
+	PluginsConfig         []FlatAgentPluginConfig `mapstructure:"plugins_config" required:"false" cty:"plugins_config" hcl:"plugins_config"`
+}
+
+// FlatMapstructure returns a new FlatAgentConfig.
+// FlatAgentConfig is an auto-generated flat version of AgentConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*AgentConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatAgentConfig)
+}
+
+// HCL2Spec returns the hcl spec of a AgentConfig.
+// This spec is used by HCL to read the fields of AgentConfig.
+// The decoded values from this spec will then be applied to a FlatAgentConfig.
+func (*FlatAgentConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"is_monitoring_disabled":   &hcldec.AttrSpec{Name: "is_monitoring_disabled", Type: cty.Bool, Required: false},
+		"is_management_disabled":   &hcldec.AttrSpec{Name: "is_management_disabled", Type: cty.Bool, Required: false},
+		"are_all_plugins_disabled": &hcldec.AttrSpec{Name: "are_all_plugins_disabled", Type: cty.Bool, Required: false},
+		"plugins_config":           &hcldec.BlockListSpec{TypeName: "plugins_config", Nested: hcldec.ObjectSpec((*FlatAgentPluginConfig)(nil).HCL2Spec())},
+	}
+	return s
+}
+
+// FlatAgentPluginConfig is an auto-generated flat version of AgentPluginConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatAgentPluginConfig struct {
+	Name         *string `mapstructure:"name" required:"true" cty:"name" hcl:"name"`
+	DesiredState *string `mapstructure:"desired_state" required:"true" cty:"desired_state" hcl:"desired_state"`
+}
+
+// FlatMapstructure returns a new FlatAgentPluginConfig.
+// FlatAgentPluginConfig is an auto-generated flat version of AgentPluginConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*AgentPluginConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatAgentPluginConfig)
+}
+
+// HCL2Spec returns the hcl spec of a AgentPluginConfig.
+// This spec is used by HCL to read the fields of AgentPluginConfig.
+// The decoded values from this spec will then be applied to a FlatAgentPluginConfig.
+func (*FlatAgentPluginConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"name":          &hcldec.AttrSpec{Name: "name", Type: cty.String, Required: false},
+		"desired_state": &hcldec.AttrSpec{Name: "desired_state", Type: cty.String, Required: false},
+	}
+	return s
+}
+
+// FlatAvailabilityConfig is an auto-generated flat version of AvailabilityConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatAvailabilityConfig struct {
+	IsLiveMigrationPreferred *bool   `mapstructure:"is_live_migration_preferred" required:"false" cty:"is_live_migration_preferred" hcl:"is_live_migration_preferred"`
+	RecoveryAction           *string `mapstructure:"recovery_action" required:"false" cty:"recovery_action" hcl:"recovery_action"`
+}
+
+// FlatMapstructure returns a new FlatAvailabilityConfig.
+// FlatAvailabilityConfig is an auto-generated flat version of AvailabilityConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*AvailabilityConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatAvailabilityConfig)
+}
+
+// HCL2Spec returns the hcl spec of a AvailabilityConfig.
+// This spec is used by HCL to read the fields of AvailabilityConfig.
+// The decoded values from this spec will then be applied to a FlatAvailabilityConfig.
+func (*FlatAvailabilityConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"is_live_migration_preferred": &hcldec.AttrSpec{Name: "is_live_migration_preferred", Type: cty.Bool, Required: false},
+		"recovery_action":             &hcldec.AttrSpec{Name: "recovery_action", Type: cty.String, Required: false},
+	}
+	return s
+}
+
+// FlatBlockVolume is an auto-generated flat version of BlockVolume.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatBlockVolume struct {
+	DisplayName    *string `mapstructure:"display_name" required:"false" cty:"display_name" hcl:"display_name"`
+	SizeInGBs      *int64  `mapstructure:"size_in_gbs" required:"false" cty:"size_in_gbs" hcl:"size_in_gbs"`
+	VpusPerGB      *int64  `mapstructure:"vpus_per_gb" required:"false" cty:"vpus_per_gb" hcl:"vpus_per_gb"`
+	AttachmentType *string `mapstructure:"attachment_type" required:"false" cty:"attachment_type" hcl:"attachment_type"`
+	Device         *string `mapstructure:"device" required:"false" cty:"device" hcl:"device"`
+	KeepBackup     *bool   `mapstructure:"keep_backup" required:"false" cty:"keep_backup" hcl:"keep_backup"`
+}
+
+// FlatMapstructure returns a new FlatBlockVolume.
+// FlatBlockVolume is an auto-generated flat version of BlockVolume.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*BlockVolume) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatBlockVolume)
+}
+
+// HCL2Spec returns the hcl spec of a BlockVolume.
+// This spec is used by HCL to read the fields of BlockVolume.
+// The decoded values from this spec will then be applied to a FlatBlockVolume.
+func (*FlatBlockVolume) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"display_name":    &hcldec.AttrSpec{Name: "display_name", Type: cty.String, Required: false},
+		"size_in_gbs":     &hcldec.AttrSpec{Name: "size_in_gbs", Type: cty.Number, Required: false},
+		"vpus_per_gb":     &hcldec.AttrSpec{Name: "vpus_per_gb", Type: cty.Number, Required: false},
+		"attachment_type": &hcldec.AttrSpec{Name: "attachment_type", Type: cty.String, Required: false},
+		"device":          &hcldec.AttrSpec{Name: "device", Type: cty.String, Required: false},
+		"keep_backup":     &hcldec.AttrSpec{Name: "keep_backup", Type: cty.Bool, Required: false},
+	}
+	return s
+}
+
 // FlatConfig is an auto-generated flat version of Config.
 // Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
 type FlatConfig struct {
-	PackerBuildName                               *string                `mapstructure:"packer_build_name" cty:"packer_build_name" hcl:"packer_build_name"`
-	PackerBuilderType                             *string                `mapstructure:"packer_builder_type" cty:"packer_builder_type" hcl:"packer_builder_type"`
-	PackerCoreVersion                             *string                `mapstructure:"packer_core_version" cty:"packer_core_version" hcl:"packer_core_version"`
-	PackerDebug                                   *bool                  `mapstructure:"packer_debug" cty:"packer_debug" hcl:"packer_debug"`
-	PackerForce                                   *bool                  `mapstructure:"packer_force" cty:"packer_force" hcl:"packer_force"`
-	PackerOnError                                 *string                `mapstructure:"packer_on_error" cty:"packer_on_error" hcl:"packer_on_error"`
-	PackerUserVars                                map[string]string      `mapstructure:"packer_user_variables" cty:"packer_user_variables" hcl:"packer_user_variables"`
-	PackerSensitiveVars                           []string               `mapstructure:"packer_sensitive_variables" cty:"packer_sensitive_variables" hcl:"packer_sensitive_variables"`
-	Type                                          *string                `mapstructure:"communicator" cty:"communicator" hcl:"communicator"`
-	PauseBeforeConnect                            *string                `mapstructure:"pause_before_connecting" cty:"pause_before_connecting" hcl:"pause_before_connecting"`
-	SSHHost                                       *string                `mapstructure:"ssh_host" cty:"ssh_host" hcl:"ssh_host"`
-	SSHPort                                       *int                   `mapstructure:"ssh_port" cty:"ssh_port" hcl:"ssh_port"`
-	SSHUsername                                   *string                `mapstructure:"ssh_username" cty:"ssh_username" hcl:"ssh_username"`
-	SSHPassword                                   *string                `mapstructure:"ssh_password" cty:"ssh_password" hcl:"ssh_password"`
-	SSHKeyPairName                                *string                `mapstructure:"ssh_keypair_name" undocumented:"true" cty:"ssh_keypair_name" hcl:"ssh_keypair_name"`
-	SSHTemporaryKeyPairName                       *string                `mapstructure:"temporary_key_pair_name" undocumented:"true" cty:"temporary_key_pair_name" hcl:"temporary_key_pair_name"`
-	SSHTemporaryKeyPairType                       *string                `mapstructure:"temporary_key_pair_type" cty:"temporary_key_pair_type" hcl:"temporary_key_pair_type"`
-	SSHTemporaryKeyPairBits                       *int                   `mapstructure:"temporary_key_pair_bits" cty:"temporary_key_pair_bits" hcl:"temporary_key_pair_bits"`
-	SSHCiphers                                    []string               `mapstructure:"ssh_ciphers" cty:"ssh_ciphers" hcl:"ssh_ciphers"`
-	SSHClearAuthorizedKeys                        *bool                  `mapstructure:"ssh_clear_authorized_keys" cty:"ssh_clear_authorized_keys" hcl:"ssh_clear_authorized_keys"`
-	SSHKEXAlgos                                   []string               `mapstructure:"ssh_key_exchange_algorithms" cty:"ssh_key_exchange_algorithms" hcl:"ssh_key_exchange_algorithms"`
-	SSHPrivateKeyFile                             *string                `mapstructure:"ssh_private_key_file" undocumented:"true" cty:"ssh_private_key_file" hcl:"ssh_private_key_file"`
-	SSHCertificateFile                            *string                `mapstructure:"ssh_certificate_file" cty:"ssh_certificate_file" hcl:"ssh_certificate_file"`
-	SSHPty                                        *bool                  `mapstructure:"ssh_pty" cty:"ssh_pty" hcl:"ssh_pty"`
-	SSHTimeout                                    *string                `mapstructure:"ssh_timeout" cty:"ssh_timeout" hcl:"ssh_timeout"`
-	SSHWaitTimeout                                *string                `mapstructure:"ssh_wait_timeout" undocumented:"true" cty:"ssh_wait_timeout" hcl:"ssh_wait_timeout"`
-	SSHAgentAuth                                  *bool                  `mapstructure:"ssh_agent_auth" undocumented:"true" cty:"ssh_agent_auth" hcl:"ssh_agent_auth"`
-	SSHDisableAgentForwarding                     *bool                  `mapstructure:"ssh_disable_agent_forwarding" cty:"ssh_disable_agent_forwarding" hcl:"ssh_disable_agent_forwarding"`
-	SSHHandshakeAttempts                          *int                   `mapstructure:"ssh_handshake_attempts" cty:"ssh_handshake_attempts" hcl:"ssh_handshake_attempts"`
-	SSHBastionHost                                *string                `mapstructure:"ssh_bastion_host" cty:"ssh_bastion_host" hcl:"ssh_bastion_host"`
-	SSHBastionPort                                *int                   `mapstructure:"ssh_bastion_port" cty:"ssh_bastion_port" hcl:"ssh_bastion_port"`
-	SSHBastionAgentAuth                           *bool                  `mapstructure:"ssh_bastion_agent_auth" cty:"ssh_bastion_agent_auth" hcl:"ssh_bastion_agent_auth"`
-	SSHBastionUsername                            *string                `mapstructure:"ssh_bastion_username" cty:"ssh_bastion_username" hcl:"ssh_bastion_username"`
-	SSHBastionPassword                            *string                `mapstructure:"ssh_bastion_password" cty:"ssh_bastion_password" hcl:"ssh_bastion_password"`
-	SSHBastionInteractive                         *bool                  `mapstructure:"ssh_bastion_interactive" cty:"ssh_bastion_interactive" hcl:"ssh_bastion_interactive"`
-	SSHBastionPrivateKeyFile                      *string                `mapstructure:"ssh_bastion_private_key_file" cty:"ssh_bastion_private_key_file" hcl:"ssh_bastion_private_key_file"`
-	SSHBastionCertificateFile                     *string                `mapstructure:"ssh_bastion_certificate_file" cty:"ssh_bastion_certificate_file" hcl:"ssh_bastion_certificate_file"`
-	SSHFileTransferMethod                         *string                `mapstructure:"ssh_file_transfer_method" cty:"ssh_file_transfer_method" hcl:"ssh_file_transfer_method"`
-	SSHProxyHost                                  *string                `mapstructure:"ssh_proxy_host" cty:"ssh_proxy_host" hcl:"ssh_proxy_host"`
-	SSHProxyPort                                  *int                   `mapstructure:"ssh_proxy_port" cty:"ssh_proxy_port" hcl:"ssh_proxy_port"`
-	SSHProxyUsername                              *string                `mapstructure:"ssh_proxy_username" cty:"ssh_proxy_username" hcl:"ssh_proxy_username"`
-	SSHProxyPassword                              *string                `mapstructure:"ssh_proxy_password" cty:"ssh_proxy_password" hcl:"ssh_proxy_password"`
-	SSHKeepAliveInterval                          *string                `mapstructure:"ssh_keep_alive_interval" cty:"ssh_keep_alive_interval" hcl:"ssh_keep_alive_interval"`
-	SSHReadWriteTimeout                           *string                `mapstructure:"ssh_read_write_timeout" cty:"ssh_read_write_timeout" hcl:"ssh_read_write_timeout"`
-	SSHRemoteTunnels                              []string               `mapstructure:"ssh_remote_tunnels" cty:"ssh_remote_tunnels" hcl:"ssh_remote_tunnels"`
-	SSHLocalTunnels                               []string               `mapstructure:"ssh_local_tunnels" cty:"ssh_local_tunnels" hcl:"ssh_local_tunnels"`
-	SSHPublicKey                                  []byte                 `mapstructure:"ssh_public_key" undocumented:"true" cty:"ssh_public_key" hcl:"ssh_public_key"`
-	SSHPrivateKey                                 []byte                 `mapstructure:"ssh_private_key" undocumented:"true" cty:"ssh_private_key" hcl:"ssh_private_key"`
-	WinRMUser                                     *string                `mapstructure:"winrm_username" cty:"winrm_username" hcl:"winrm_username"`
-	WinRMPassword                                 *string                `mapstructure:"winrm_password" cty:"winrm_password" hcl:"winrm_password"`
-	WinRMHost                                     *string                `mapstructure:"winrm_host" cty:"winrm_host" hcl:"winrm_host"`
-	WinRMNoProxy                                  *bool                  `mapstructure:"winrm_no_proxy" cty:"winrm_no_proxy" hcl:"winrm_no_proxy"`
-	WinRMPort                                     *int                   `mapstructure:"winrm_port" cty:"winrm_port" hcl:"winrm_port"`
-	WinRMTimeout                                  *string                `mapstructure:"winrm_timeout" cty:"winrm_timeout" hcl:"winrm_timeout"`
-	WinRMUseSSL                                   *bool                  `mapstructure:"winrm_use_ssl" cty:"winrm_use_ssl" hcl:"winrm_use_ssl"`
-	WinRMInsecure                                 *bool                  `mapstructure:"winrm_insecure" cty:"winrm_insecure" hcl:"winrm_insecure"`
-	WinRMUseNTLM                                  *bool                  `mapstructure:"winrm_use_ntlm" cty:"winrm_use_ntlm" hcl:"winrm_use_ntlm"`
-	InstancePrincipals                            *bool                  `mapstructure:"use_instance_principals" cty:"use_instance_principals" hcl:"use_instance_principals"`
-	SkipCreateImage                               *bool                  `mapstructure:"skip_create_image" required:"false" cty:"skip_create_image" hcl:"skip_create_image"`
-	AccessCfgFile                                 *string                `mapstructure:"access_cfg_file" cty:"access_cfg_file" hcl:"access_cfg_file"`
-	AccessCfgFileAccount                          *string                `mapstructure:"access_cfg_file_account" cty:"access_cfg_file_account" hcl:"access_cfg_file_account"`
-	UserID                                        *string                `mapstructure:"user_ocid" cty:"user_ocid" hcl:"user_ocid"`
-	TenancyID                                     *string                `mapstructure:"tenancy_ocid" cty:"tenancy_ocid" hcl:"tenancy_ocid"`
-	Region                                        *string                `mapstructure:"region" cty:"region" hcl:"region"`
-	Fingerprint                                   *string                `mapstructure:"fingerprint" cty:"fingerprint" hcl:"fingerprint"`
-	Key                                           *string                `mapstructure:"key" cty:"key" hcl:"key"`
-	KeyFile                                       *string                `mapstructure:"key_file" cty:"key_file" hcl:"key_file"`
-	PassPhrase                                    *string                `mapstructure:"pass_phrase" cty:"pass_phrase" hcl:"pass_phrase"`
-	UsePrivateIP                                  *bool                  `mapstructure:"use_private_ip" cty:"use_private_ip" hcl:"use_private_ip"`
-	SecurityTokenFilePath                         *string                `mapstructure:"security_token_file" cty:"security_token_file" hcl:"security_token_file"`
-	AvailabilityDomain                            *string                `mapstructure:"availability_domain" cty:"availability_domain" hcl:"availability_domain"`
-	CompartmentID                                 *string                `mapstructure:"compartment_ocid" cty:"compartment_ocid" hcl:"compartment_ocid"`
-	BaseImageID                                   *string                `mapstructure:"base_image_ocid" cty:"base_image_ocid" hcl:"base_image_ocid"`
-	BaseImageFilter                               *FlatListImagesRequest `mapstructure:"base_image_filter" cty:"base_image_filter" hcl:"base_image_filter"`
-	ImageName                                     *string                `mapstructure:"image_name" cty:"image_name" hcl:"image_name"`
-	ImageCompartmentID                            *string                `mapstructure:"image_compartment_ocid" cty:"image_compartment_ocid" hcl:"image_compartment_ocid"`
-	LaunchMode                                    *string                `mapstructure:"image_launch_mode" cty:"image_launch_mode" hcl:"image_launch_mode"`
-	NicAttachmentType                             *string                `mapstructure:"nic_attachment_type" cty:"nic_attachment_type" hcl:"nic_attachment_type"`
-	InstanceName                                  *string                `mapstructure:"instance_name" cty:"instance_name" hcl:"instance_name"`
-	InstanceTags                                  map[string]string      `mapstructure:"instance_tags" cty:"instance_tags" hcl:"instance_tags"`
-	InstanceDefinedTagsJson                       *string                `mapstructure:"instance_defined_tags_json" required:"false" cty:"instance_defined_tags_json" hcl:"instance_defined_tags_json"`
-	Shape                                         *string                `mapstructure:"shape" cty:"shape" hcl:"shape"`
-	ShapeConfig                                   *FlatFlexShapeConfig   `mapstructure:"shape_config" cty:"shape_config" hcl:"shape_config"`
-	BootVolumeSizeInGBs                           *int64                 `mapstructure:"disk_size" cty:"disk_size" hcl:"disk_size"`
-	InstanceOptionsAreLegacyImdsEndpointsDisabled *bool                  `mapstructure:"instance_options_are_legacy_imds_endpoints_disabled" required:"false" cty:"instance_options_are_legacy_imds_endpoints_disabled" hcl:"instance_options_are_legacy_imds_endpoints_disabled"`
-	Metadata                                      map[string]string      `mapstructure:"metadata" cty:"metadata" hcl:"metadata"`
-	UserData                                      *string                `mapstructure:"user_data" cty:"user_data" hcl:"user_data"`
-	UserDataFile                                  *string                `mapstructure:"user_data_file" cty:"user_data_file" hcl:"user_data_file"`
-	SubnetID                                      *string                `mapstructure:"subnet_ocid" cty:"subnet_ocid" hcl:"subnet_ocid"`
-	CreateVnicDetails                             *FlatCreateVNICDetails `mapstructure:"create_vnic_details" cty:"create_vnic_details" hcl:"create_vnic_details"`
-	Tags                                          map[string]string      `mapstructure:"tags" cty:"tags" hcl:"tags"`
-	DefinedTagsJson                               *string                `mapstructure:"defined_tags_json" required:"false" cty:"defined_tags_json" hcl:"defined_tags_json"`
+	PackerBuildName                               *string                        `mapstructure:"packer_build_name" cty:"packer_build_name" hcl:"packer_build_name"`
+	PackerBuilderType                             *string                        `mapstructure:"packer_builder_type" cty:"packer_builder_type" hcl:"packer_builder_type"`
+	PackerCoreVersion                             *string                        `mapstructure:"packer_core_version" cty:"packer_core_version" hcl:"packer_core_version"`
+	PackerDebug                                   *bool                          `mapstructure:"packer_debug" cty:"packer_debug" hcl:"packer_debug"`
+	PackerForce                                   *bool                          `mapstructure:"packer_force" cty:"packer_force" hcl:"packer_force"`
+	PackerOnError                                 *string                        `mapstructure:"packer_on_error" cty:"packer_on_error" hcl:"packer_on_error"`
+	PackerUserVars                                map[string]string              `mapstructure:"packer_user_variables" cty:"packer_user_variables" hcl:"packer_user_variables"`
+	PackerSensitiveVars                           []string                       `mapstructure:"packer_sensitive_variables" cty:"packer_sensitive_variables" hcl:"packer_sensitive_variables"`
+	Type                                          *string                        `mapstructure:"communicator" cty:"communicator" hcl:"communicator"`
+	PauseBeforeConnect                            *string                        `mapstructure:"pause_before_connecting" cty:"pause_before_connecting" hcl:"pause_before_connecting"`
+	SSHHost                                       *string                        `mapstructure:"ssh_host" cty:"ssh_host" hcl:"ssh_host"`
+	SSHPort                                       *int                           `mapstructure:"ssh_port" cty:"ssh_port" hcl:"ssh_port"`
+	SSHUsername                                   *string                        `mapstructure:"ssh_username" cty:"ssh_username" hcl:"ssh_username"`
+	SSHPassword                                   *string                        `mapstructure:"ssh_password" cty:"ssh_password" hcl:"ssh_password"`
+	SSHKeyPairName                                *string                        `mapstructure:"ssh_keypair_name" undocumented:"true" cty:"ssh_keypair_name" hcl:"ssh_keypair_name"`
+	SSHTemporaryKeyPairName                       *string                        `mapstructure:"temporary_key_pair_name" undocumented:"true" cty:"temporary_key_pair_name" hcl:"temporary_key_pair_name"`
+	SSHTemporaryKeyPairType                       *string                        `mapstructure:"temporary_key_pair_type" cty:"temporary_key_pair_type" hcl:"temporary_key_pair_type"`
+	SSHTemporaryKeyPairBits                       *int                           `mapstructure:"temporary_key_pair_bits" cty:"temporary_key_pair_bits" hcl:"temporary_key_pair_bits"`
+	SSHCiphers                                    []string                       `mapstructure:"ssh_ciphers" cty:"ssh_ciphers" hcl:"ssh_ciphers"`
+	SSHClearAuthorizedKeys                        *bool                          `mapstructure:"ssh_clear_authorized_keys" cty:"ssh_clear_authorized_keys" hcl:"ssh_clear_authorized_keys"`
+	SSHKEXAlgos                                   []string                       `mapstructure:"ssh_key_exchange_algorithms" cty:"ssh_key_exchange_algorithms" hcl:"ssh_key_exchange_algorithms"`
+	SSHPrivateKeyFile                             *string                        `mapstructure:"ssh_private_key_file" undocumented:"true" cty:"ssh_private_key_file" hcl:"ssh_private_key_file"`
+	SSHCertificateFile                            *string                        `mapstructure:"ssh_certificate_file" cty:"ssh_certificate_file" hcl:"ssh_certificate_file"`
+	SSHPty                                        *bool                          `mapstructure:"ssh_pty" cty:"ssh_pty" hcl:"ssh_pty"`
+	SSHTimeout                                    *string                        `mapstructure:"ssh_timeout" cty:"ssh_timeout" hcl:"ssh_timeout"`
+	SSHWaitTimeout                                *string                        `mapstructure:"ssh_wait_timeout" undocumented:"true" cty:"ssh_wait_timeout" hcl:"ssh_wait_timeout"`
+	SSHAgentAuth                                  *bool                          `mapstructure:"ssh_agent_auth" undocumented:"true" cty:"ssh_agent_auth" hcl:"ssh_agent_auth"`
+	SSHDisableAgentForwarding                     *bool                          `mapstructure:"ssh_disable_agent_forwarding" cty:"ssh_disable_agent_forwarding" hcl:"ssh_disable_agent_forwarding"`
+	SSHHandshakeAttempts                          *int                           `mapstructure:"ssh_handshake_attempts" cty:"ssh_handshake_attempts" hcl:"ssh_handshake_attempts"`
+	SSHBastionHost                                *string                        `mapstructure:"ssh_bastion_host" cty:"ssh_bastion_host" hcl:"ssh_bastion_host"`
+	SSHBastionPort                                *int                           `mapstructure:"ssh_bastion_port" cty:"ssh_bastion_port" hcl:"ssh_bastion_port"`
+	SSHBastionAgentAuth                           *bool                          `mapstructure:"ssh_bastion_agent_auth" cty:"ssh_bastion_agent_auth" hcl:"ssh_bastion_agent_auth"`
+	SSHBastionUsername                            *string                        `mapstructure:"ssh_bastion_username" cty:"ssh_bastion_username" hcl:"ssh_bastion_username"`
+	SSHBastionPassword                            *string                        `mapstructure:"ssh_bastion_password" cty:"ssh_bastion_password" hcl:"ssh_bastion_password"`
+	SSHBastionInteractive                         *bool                          `mapstructure:"ssh_bastion_interactive" cty:"ssh_bastion_interactive" hcl:"ssh_bastion_interactive"`
+	SSHBastionPrivateKeyFile                      *string                        `mapstructure:"ssh_bastion_private_key_file" cty:"ssh_bastion_private_key_file" hcl:"ssh_bastion_private_key_file"`
+	SSHBastionCertificateFile                     *string                        `mapstructure:"ssh_bastion_certificate_file" cty:"ssh_bastion_certificate_file" hcl:"ssh_bastion_certificate_file"`
+	SSHFileTransferMethod                         *string                        `mapstructure:"ssh_file_transfer_method" cty:"ssh_file_transfer_method" hcl:"ssh_file_transfer_method"`
+	SSHProxyHost                                  *string                        `mapstructure:"ssh_proxy_host" cty:"ssh_proxy_host" hcl:"ssh_proxy_host"`
+	SSHProxyPort                                  *int                           `mapstructure:"ssh_proxy_port" cty:"ssh_proxy_port" hcl:"ssh_proxy_port"`
+	SSHProxyUsername                              *string                        `mapstructure:"ssh_proxy_username" cty:"ssh_proxy_username" hcl:"ssh_proxy_username"`
+	SSHProxyPassword                              *string                        `mapstructure:"ssh_proxy_password" cty:"ssh_proxy_password" hcl:"ssh_proxy_password"`
+	SSHKeepAliveInterval                          *string                        `mapstructure:"ssh_keep_alive_interval" cty:"ssh_keep_alive_interval" hcl:"ssh_keep_alive_interval"`
+	SSHReadWriteTimeout                           *string                        `mapstructure:"ssh_read_write_timeout" cty:"ssh_read_write_timeout" hcl:"ssh_read_write_timeout"`
+	SSHRemoteTunnels                              []string                       `mapstructure:"ssh_remote_tunnels" cty:"ssh_remote_tunnels" hcl:"ssh_remote_tunnels"`
+	SSHLocalTunnels                               []string                       `mapstructure:"ssh_local_tunnels" cty:"ssh_local_tunnels" hcl:"ssh_local_tunnels"`
+	SSHPublicKey                                  []byte                         `mapstructure:"ssh_public_key" undocumented:"true" cty:"ssh_public_key" hcl:"ssh_public_key"`
+	SSHPrivateKey                                 []byte                         `mapstructure:"ssh_private_key" undocumented:"true" cty:"ssh_private_key" hcl:"ssh_private_key"`
+	WinRMUser                                     *string                        `mapstructure:"winrm_username" cty:"winrm_username" hcl:"winrm_username"`
+	WinRMPassword                                 *string                        `mapstructure:"winrm_password" cty:"winrm_password" hcl:"winrm_password"`
+	WinRMHost                                     *string                        `mapstructure:"winrm_host" cty:"winrm_host" hcl:"winrm_host"`
+	WinRMNoProxy                                  *bool                          `mapstructure:"winrm_no_proxy" cty:"winrm_no_proxy" hcl:"winrm_no_proxy"`
+	WinRMPort                                     *int                           `mapstructure:"winrm_port" cty:"winrm_port" hcl:"winrm_port"`
+	WinRMTimeout                                  *string                        `mapstructure:"winrm_timeout" cty:"winrm_timeout" hcl:"winrm_timeout"`
+	WinRMUseSSL                                   *bool                          `mapstructure:"winrm_use_ssl" cty:"winrm_use_ssl" hcl:"winrm_use_ssl"`
+	WinRMInsecure                                 *bool                          `mapstructure:"winrm_insecure" cty:"winrm_insecure" hcl:"winrm_insecure"`
+	WinRMUseNTLM                                  *bool                          `mapstructure:"winrm_use_ntlm" cty:"winrm_use_ntlm" hcl:"winrm_use_ntlm"`
+	InstancePrincipals                            *bool                          `mapstructure:"use_instance_principals" cty:"use_instance_principals" hcl:"use_instance_principals"`
+	AccessCfgFile                                 *string                        `mapstructure:"access_cfg_file" cty:"access_cfg_file" hcl:"access_cfg_file"`
+	AccessCfgFileAccount                          *string                        `mapstructure:"access_cfg_file_account" cty:"access_cfg_file_account" hcl:"access_cfg_file_account"`
+	UserID                                        *string                        `mapstructure:"user_ocid" cty:"user_ocid" hcl:"user_ocid"`
+	TenancyID                                     *string                        `mapstructure:"tenancy_ocid" cty:"tenancy_ocid" hcl:"tenancy_ocid"`
+	Region                                        *string                        `mapstructure:"region" cty:"region" hcl:"region"`
+	Fingerprint                                   *string                        `mapstructure:"fingerprint" cty:"fingerprint" hcl:"fingerprint"`
+	Key                                           *string                        `mapstructure:"key" cty:"key" hcl:"key"`
+	KeyFile                                       *string                        `mapstructure:"key_file" cty:"key_file" hcl:"key_file"`
+	PassPhrase                                    *string                        `mapstructure:"pass_phrase" cty:"pass_phrase" hcl:"pass_phrase"`
+	SecurityTokenFilePath                         *string                        `mapstructure:"security_token_file" cty:"security_token_file" hcl:"security_token_file"`
+	SkipCreateImage                               *bool                          `mapstructure:"skip_create_image" required:"false" cty:"skip_create_image" hcl:"skip_create_image"`
+	UsePrivateIP                                  *bool                          `mapstructure:"use_private_ip" cty:"use_private_ip" hcl:"use_private_ip"`
+	AvailabilityDomain                            *string                        `mapstructure:"availability_domain" cty:"availability_domain" hcl:"availability_domain"`
+	CompartmentID                                 *string                        `mapstructure:"compartment_ocid" cty:"compartment_ocid" hcl:"compartment_ocid"`
+	ChangeInitialPassword                         *bool                          `mapstructure:"change_initial_password" required:"false" cty:"change_initial_password" hcl:"change_initial_password"`
+	NewWinRMPassword                              *string                        `mapstructure:"new_winrm_password" required:"false" cty:"new_winrm_password" hcl:"new_winrm_password"`
+	BaseImageID                                   *string                        `mapstructure:"base_image_ocid" cty:"base_image_ocid" hcl:"base_image_ocid"`
+	BaseImageFilter                               *FlatListImagesRequest         `mapstructure:"base_image_filter" cty:"base_image_filter" hcl:"base_image_filter"`
+	ImageName                                     *string                        `mapstructure:"image_name" cty:"image_name" hcl:"image_name"`
+	ImageCompartmentID                            *string                        `mapstructure:"image_compartment_ocid" cty:"image_compartment_ocid" hcl:"image_compartment_ocid"`
+	LaunchMode                                    *string                        `mapstructure:"image_launch_mode" cty:"image_launch_mode" hcl:"image_launch_mode"`
+	NicAttachmentType                             *string                        `mapstructure:"nic_attachment_type" cty:"nic_attachment_type" hcl:"nic_attachment_type"`
+	ArtifactType                                  *string                        `mapstructure:"artifact_type" cty:"artifact_type" hcl:"artifact_type"`
+	BootVolumeBackupType                          *string                        `mapstructure:"boot_volume_backup_type" cty:"boot_volume_backup_type" hcl:"boot_volume_backup_type"`
+	ImageRetention                                *FlatImageRetention            `mapstructure:"image_retention" required:"false" cty:"image_retention" hcl:"image_retention"`
+	ForceDeleteExistingImage                      *bool                          `mapstructure:"force_delete_existing_image" required:"false" cty:"force_delete_existing_image" hcl:"force_delete_existing_image"`
+	CopyToRegions                                 []string                       `mapstructure:"copy_to_regions" cty:"copy_to_regions" hcl:"copy_to_regions"`
+	CopyImageBucketName                           *string                        `mapstructure:"copy_image_bucket_name" cty:"copy_image_bucket_name" hcl:"copy_image_bucket_name"`
+	InstanceName                                  *string                        `mapstructure:"instance_name" cty:"instance_name" hcl:"instance_name"`
+	InstanceTags                                  map[string]string              `mapstructure:"instance_tags" cty:"instance_tags" hcl:"instance_tags"`
+	InstanceDefinedTagsJson                       *string                        `mapstructure:"instance_defined_tags_json" required:"false" cty:"instance_defined_tags_json" hcl:"instance_defined_tags_json"`
+	Shape                                         *string                        `mapstructure:"shape" cty:"shape" hcl:"shape"`
+	ShapeConfig                                   *FlatFlexShapeConfig           `mapstructure:"shape_config" cty:"shape_config" hcl:"shape_config"`
+	BootVolumeSizeInGBs                           *int64                         `mapstructure:"disk_size" cty:"disk_size" hcl:"disk_size"`
+	BootVolumeKmsKeyID                            *string                        `mapstructure:"boot_volume_kms_key_ocid" required:"false" cty:"boot_volume_kms_key_ocid" hcl:"boot_volume_kms_key_ocid"`
+	BootVolumeVpusPerGB                           *int64                         `mapstructure:"boot_volume_vpus_per_gb" required:"false" cty:"boot_volume_vpus_per_gb" hcl:"boot_volume_vpus_per_gb"`
+	InstanceOptionsAreLegacyImdsEndpointsDisabled *bool                          `mapstructure:"instance_options_are_legacy_imds_endpoints_disabled" required:"false" cty:"instance_options_are_legacy_imds_endpoints_disabled" hcl:"instance_options_are_legacy_imds_endpoints_disabled"`
+	AvailabilityDomains                           []string                       `mapstructure:"availability_domains" required:"false" cty:"availability_domains" hcl:"availability_domains"`
+	FaultDomains                                  []string                       `mapstructure:"fault_domains" required:"false" cty:"fault_domains" hcl:"fault_domains"`
+	FallbackShapes                                []FlatFallbackShape            `mapstructure:"fallback_shapes" required:"false" cty:"fallback_shapes" hcl:"fallback_shapes"`
+	PreflightValidation                           *bool                          `mapstructure:"preflight_validation" required:"false" cty:"preflight_validation" hcl:"preflight_validation"`
+	InstanceLaunchTimeout                         *string                        `mapstructure:"instance_launch_timeout" required:"false" cty:"instance_launch_timeout" hcl:"instance_launch_timeout"`
+	ImageCreateTimeout                            *string                        `mapstructure:"image_create_timeout" required:"false" cty:"image_create_timeout" hcl:"image_create_timeout"`
+	InstanceTerminateTimeout                      *string                        `mapstructure:"instance_terminate_timeout" required:"false" cty:"instance_terminate_timeout" hcl:"instance_terminate_timeout"`
+	ResourceTimeout                               *string                        `mapstructure:"resource_timeout" required:"false" cty:"resource_timeout" hcl:"resource_timeout"`
+	RunCommandBucketName                          *string                        `mapstructure:"run_command_bucket_name" required:"false" cty:"run_command_bucket_name" hcl:"run_command_bucket_name"`
+	RunCommandTimeout                             *string                        `mapstructure:"run_command_timeout" required:"false" cty:"run_command_timeout" hcl:"run_command_timeout"`
+	AgentConfig                                   *FlatAgentConfig               `mapstructure:"agent_config" required:"false" cty:"agent_config" hcl:"agent_config"`
+	AvailabilityConfig                            *FlatAvailabilityConfig        `mapstructure:"availability_config" required:"false" cty:"availability_config" hcl:"availability_config"`
+	LaunchOptions                                 *FlatLaunchOptions             `mapstructure:"launch_options" required:"false" cty:"launch_options" hcl:"launch_options"`
+	PlatformConfig                                *FlatPlatformConfig            `mapstructure:"platform_config" required:"false" cty:"platform_config" hcl:"platform_config"`
+	PreemptibleInstanceConfig                     *FlatPreemptibleInstanceConfig `mapstructure:"preemptible_instance_config" required:"false" cty:"preemptible_instance_config" hcl:"preemptible_instance_config"`
+	PreemptibleRelaunchAttempts                   *int                           `mapstructure:"preemptible_relaunch_attempts" required:"false" cty:"preemptible_relaunch_attempts" hcl:"preemptible_relaunch_attempts"`
+	CapacityReservationID                         *string                        `mapstructure:"capacity_reservation_ocid" required:"false" cty:"capacity_reservation_ocid" hcl:"capacity_reservation_ocid"`
+	DedicatedVmHostID                             *string                        `mapstructure:"dedicated_vm_host_ocid" required:"false" cty:"dedicated_vm_host_ocid" hcl:"dedicated_vm_host_ocid"`
+	BlockVolumes                                  []FlatBlockVolume              `mapstructure:"block_volumes" required:"false" cty:"block_volumes" hcl:"block_volumes"`
+	Metadata                                      map[string]string              `mapstructure:"metadata" cty:"metadata" hcl:"metadata"`
+	UserData                                      *string                        `mapstructure:"user_data" cty:"user_data" hcl:"user_data"`
+	UserDataFile                                  *string                        `mapstructure:"user_data_file" cty:"user_data_file" hcl:"user_data_file"`
+	SubnetID                                      *string                        `mapstructure:"subnet_ocid" cty:"subnet_ocid" hcl:"subnet_ocid"`
+	CreateVnicDetails                             *FlatCreateVNICDetails         `mapstructure:"create_vnic_details" cty:"create_vnic_details" hcl:"create_vnic_details"`
+	TemporaryVcnCidrBlock                         *string                        `mapstructure:"temporary_vcn_cidr_block" cty:"temporary_vcn_cidr_block" hcl:"temporary_vcn_cidr_block"`
+	TemporaryNetworkSourceCidrs                   []string                       `mapstructure:"temporary_network_source_cidrs" cty:"temporary_network_source_cidrs" hcl:"temporary_network_source_cidrs"`
+	BastionID                                     *string                        `mapstructure:"bastion_ocid" cty:"bastion_ocid" hcl:"bastion_ocid"`
+	BastionSessionType                            *string                        `mapstructure:"bastion_session_type" cty:"bastion_session_type" hcl:"bastion_session_type"`
+	BastionSessionTTL                             *string                        `mapstructure:"bastion_session_ttl" cty:"bastion_session_ttl" hcl:"bastion_session_ttl"`
+	Tags                                          map[string]string              `mapstructure:"tags" cty:"tags" hcl:"tags"`
+	DefinedTagsJson                               *string                        `mapstructure:"defined_tags_json" required:"false" cty:"defined_tags_json" hcl:"defined_tags_json"`
 }
 
 // FlatMapstructure returns a new FlatConfig.
@@ -174,7 +320,6 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"winrm_insecure":               &hcldec.AttrSpec{Name: "winrm_insecure", Type: cty.Bool, Required: false},
 		"winrm_use_ntlm":               &hcldec.AttrSpec{Name: "winrm_use_ntlm", Type: cty.Bool, Required: false},
 		"use_instance_principals":      &hcldec.AttrSpec{Name: "use_instance_principals", Type: cty.Bool, Required: false},
-		"skip_create_image":            &hcldec.AttrSpec{Name: "skip_create_image", Type: cty.Bool, Required: false},
 		"access_cfg_file":              &hcldec.AttrSpec{Name: "access_cfg_file", Type: cty.String, Required: false},
 		"access_cfg_file_account":      &hcldec.AttrSpec{Name: "access_cfg_file_account", Type: cty.String, Required: false},
 		"user_ocid":                    &hcldec.AttrSpec{Name: "user_ocid", Type: cty.String, Required: false},
@@ -184,30 +329,65 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"key":                          &hcldec.AttrSpec{Name: "key", Type: cty.String, Required: false},
 		"key_file":                     &hcldec.AttrSpec{Name: "key_file", Type: cty.String, Required: false},
 		"pass_phrase":                  &hcldec.AttrSpec{Name: "pass_phrase", Type: cty.String, Required: false},
-		"use_private_ip":               &hcldec.AttrSpec{Name: "use_private_ip", Type: cty.Bool, Required: false},
 		"security_token_file":          &hcldec.AttrSpec{Name: "security_token_file", Type: cty.String, Required: false},
+		"skip_create_image":            &hcldec.AttrSpec{Name: "skip_create_image", Type: cty.Bool, Required: false},
+		"use_private_ip":               &hcldec.AttrSpec{Name: "use_private_ip", Type: cty.Bool, Required: false},
 		"availability_domain":          &hcldec.AttrSpec{Name: "availability_domain", Type: cty.String, Required: false},
 		"compartment_ocid":             &hcldec.AttrSpec{Name: "compartment_ocid", Type: cty.String, Required: false},
+		"change_initial_password":      &hcldec.AttrSpec{Name: "change_initial_password", Type: cty.Bool, Required: false},
+		"new_winrm_password":           &hcldec.AttrSpec{Name: "new_winrm_password", Type: cty.String, Required: false},
 		"base_image_ocid":              &hcldec.AttrSpec{Name: "base_image_ocid", Type: cty.String, Required: false},
 		"base_image_filter":            &hcldec.BlockSpec{TypeName: "base_image_filter", Nested: hcldec.ObjectSpec((*FlatListImagesRequest)(nil).HCL2Spec())},
 		"image_name":                   &hcldec.AttrSpec{Name: "image_name", Type: cty.String, Required: false},
 		"image_compartment_ocid":       &hcldec.AttrSpec{Name: "image_compartment_ocid", Type: cty.String, Required: false},
 		"image_launch_mode":            &hcldec.AttrSpec{Name: "image_launch_mode", Type: cty.String, Required: false},
 		"nic_attachment_type":          &hcldec.AttrSpec{Name: "nic_attachment_type", Type: cty.String, Required: false},
+		"artifact_type":                &hcldec.AttrSpec{Name: "artifact_type", Type: cty.String, Required: false},
+		"boot_volume_backup_type":      &hcldec.AttrSpec{Name: "boot_volume_backup_type", Type: cty.String, Required: false},
+		"image_retention":              &hcldec.BlockSpec{TypeName: "image_retention", Nested: hcldec.ObjectSpec((*FlatImageRetention)(nil).HCL2Spec())},
+		"force_delete_existing_image":  &hcldec.AttrSpec{Name: "force_delete_existing_image", Type: cty.Bool, Required: false},
+		"copy_to_regions":              &hcldec.AttrSpec{Name: "copy_to_regions", Type: cty.List(cty.String), Required: false},
+		"copy_image_bucket_name":       &hcldec.AttrSpec{Name: "copy_image_bucket_name", Type: cty.String, Required: false},
 		"instance_name":                &hcldec.AttrSpec{Name: "instance_name", Type: cty.String, Required: false},
 		"instance_tags":                &hcldec.AttrSpec{Name: "instance_tags", Type: cty.Map(cty.String), Required: false},
 		"instance_defined_tags_json":   &hcldec.AttrSpec{Name: "instance_defined_tags_json", Type: cty.String, Required: false},
 		"shape":                        &hcldec.AttrSpec{Name: "shape", Type: cty.String, Required: false},
 		"shape_config":                 &hcldec.BlockSpec{TypeName: "shape_config", Nested: hcldec.ObjectSpec((*FlatFlexShapeConfig)(nil).HCL2Spec())},
 		"disk_size":                    &hcldec.AttrSpec{Name: "disk_size", Type: cty.Number, Required: false},
+		"boot_volume_kms_key_ocid":     &hcldec.AttrSpec{Name: "boot_volume_kms_key_ocid", Type: cty.String, Required: false},
+		"boot_volume_vpus_per_gb":      &hcldec.AttrSpec{Name: "boot_volume_vpus_per_gb", Type: cty.Number, Required: false},
 		"instance_options_are_legacy_imds_endpoints_disabled": &hcldec.AttrSpec{Name: "instance_options_are_legacy_imds_endpoints_disabled", Type: cty.Bool, Required: false},
-		"metadata":            &hcldec.AttrSpec{Name: "metadata", Type: cty.Map(cty.String), Required: false},
-		"user_data":           &hcldec.AttrSpec{Name: "user_data", Type: cty.String, Required: false},
-		"user_data_file":      &hcldec.AttrSpec{Name: "user_data_file", Type: cty.String, Required: false},
-		"subnet_ocid":         &hcldec.AttrSpec{Name: "subnet_ocid", Type: cty.String, Required: false},
-		"create_vnic_details": &hcldec.BlockSpec{TypeName: "create_vnic_details", Nested: hcldec.ObjectSpec((*FlatCreateVNICDetails)(nil).HCL2Spec())},
-		"tags":                &hcldec.AttrSpec{Name: "tags", Type: cty.Map(cty.String), Required: false},
-		"defined_tags_json":   &hcldec.AttrSpec{Name: "defined_tags_json", Type: cty.String, Required: false},
+		"availability_domains":                                &hcldec.AttrSpec{Name: "availability_domains", Type: cty.List(cty.String), Required: false},
+		"fault_domains":                                       &hcldec.AttrSpec{Name: "fault_domains", Type: cty.List(cty.String), Required: false},
+		"fallback_shapes":                                     &hcldec.BlockListSpec{TypeName: "fallback_shapes", Nested: hcldec.ObjectSpec((*FlatFallbackShape)(nil).HCL2Spec())},
+		"preflight_validation":                                &hcldec.AttrSpec{Name: "preflight_validation", Type: cty.Bool, Required: false},
+		"instance_launch_timeout":                             &hcldec.AttrSpec{Name: "instance_launch_timeout", Type: cty.String, Required: false},
+		"image_create_timeout":                                &hcldec.AttrSpec{Name: "image_create_timeout", Type: cty.String, Required: false},
+		"instance_terminate_timeout":                          &hcldec.AttrSpec{Name: "instance_terminate_timeout", Type: cty.String, Required: false},
+		"resource_timeout":                                    &hcldec.AttrSpec{Name: "resource_timeout", Type: cty.String, Required: false},
+		"run_command_bucket_name":                             &hcldec.AttrSpec{Name: "run_command_bucket_name", Type: cty.String, Required: false},
+		"run_command_timeout":                                 &hcldec.AttrSpec{Name: "run_command_timeout", Type: cty.String, Required: false},
+		"agent_config":                                        &hcldec.BlockSpec{TypeName: "agent_config", Nested: hcldec.ObjectSpec((*FlatAgentConfig)(nil).HCL2Spec())},
+		"availability_config":                                 &hcldec.BlockSpec{TypeName: "availability_config", Nested: hcldec.ObjectSpec((*FlatAvailabilityConfig)(nil).HCL2Spec())},
+		"launch_options":                                      &hcldec.BlockSpec{TypeName: "launch_options", Nested: hcldec.ObjectSpec((*FlatLaunchOptions)(nil).HCL2Spec())},
+		"platform_config":                                     &hcldec.BlockSpec{TypeName: "platform_config", Nested: hcldec.ObjectSpec((*FlatPlatformConfig)(nil).HCL2Spec())},
+		"preemptible_instance_config":                         &hcldec.BlockSpec{TypeName: "preemptible_instance_config", Nested: hcldec.ObjectSpec((*FlatPreemptibleInstanceConfig)(nil).HCL2Spec())},
+		"preemptible_relaunch_attempts":                       &hcldec.AttrSpec{Name: "preemptible_relaunch_attempts", Type: cty.Number, Required: false},
+		"capacity_reservation_ocid":                           &hcldec.AttrSpec{Name: "capacity_reservation_ocid", Type: cty.String, Required: false},
+		"dedicated_vm_host_ocid":                              &hcldec.AttrSpec{Name: "dedicated_vm_host_ocid", Type: cty.String, Required: false},
+		"block_volumes":                                       &hcldec.BlockListSpec{TypeName: "block_volumes", Nested: hcldec.ObjectSpec((*FlatBlockVolume)(nil).HCL2Spec())},
+		"metadata":                                            &hcldec.AttrSpec{Name: "metadata", Type: cty.Map(cty.String), Required: false},
+		"user_data":                                           &hcldec.AttrSpec{Name: "user_data", Type: cty.String, Required: false},
+		"user_data_file":                                      &hcldec.AttrSpec{Name: "user_data_file", Type: cty.String, Required: false},
+		"subnet_ocid":                                         &hcldec.AttrSpec{Name: "subnet_ocid", Type: cty.String, Required: false},
+		"create_vnic_details":                                 &hcldec.BlockSpec{TypeName: "create_vnic_details", Nested: hcldec.ObjectSpec((*FlatCreateVNICDetails)(nil).HCL2Spec())},
+		"temporary_vcn_cidr_block":                            &hcldec.AttrSpec{Name: "temporary_vcn_cidr_block", Type: cty.String, Required: false},
+		"temporary_network_source_cidrs":                      &hcldec.AttrSpec{Name: "temporary_network_source_cidrs", Type: cty.List(cty.String), Required: false},
+		"bastion_ocid":                                        &hcldec.AttrSpec{Name: "bastion_ocid", Type: cty.String, Required: false},
+		"bastion_session_type":                                &hcldec.AttrSpec{Name: "bastion_session_type", Type: cty.String, Required: false},
+		"bastion_session_ttl":                                 &hcldec.AttrSpec{Name: "bastion_session_ttl", Type: cty.String, Required: false},
+		"tags":                                                &hcldec.AttrSpec{Name: "tags", Type: cty.Map(cty.String), Required: false},
+		"defined_tags_json":                                   &hcldec.AttrSpec{Name: "defined_tags_json", Type: cty.String, Required: false},
 	}
 	return s
 }
@@ -251,6 +431,31 @@ func (*FlatCreateVNICDetails) HCL2Spec() map[string]hcldec.Spec {
 	return s
 }
 
+// FlatFallbackShape is an auto-generated flat version of FallbackShape.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatFallbackShape struct {
+	Shape       *string              `mapstructure:"shape" required:"true" cty:"shape" hcl:"shape"`
+	ShapeConfig *FlatFlexShapeConfig `mapstructure:"shape_config" required:"false" cty:"shape_config" hcl:"shape_config"`
+}
+
+// FlatMapstructure returns a new FlatFallbackShape.
+// FlatFallbackShape is an auto-generated flat version of FallbackShape.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*FallbackShape) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatFallbackShape)
+}
+
+// HCL2Spec returns the hcl spec of a FallbackShape.
+// This spec is used by HCL to read the fields of FallbackShape.
+// The decoded values from this spec will then be applied to a FlatFallbackShape.
+func (*FlatFallbackShape) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"shape":        &hcldec.AttrSpec{Name: "shape", Type: cty.String, Required: false},
+		"shape_config": &hcldec.BlockSpec{TypeName: "shape_config", Nested: hcldec.ObjectSpec((*FlatFlexShapeConfig)(nil).HCL2Spec())},
+	}
+	return s
+}
+
 // FlatFlexShapeConfig is an auto-generated flat version of FlexShapeConfig.
 // Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
 type FlatFlexShapeConfig struct {
@@ -278,6 +483,70 @@ func (*FlatFlexShapeConfig) HCL2Spec() map[string]hcldec.Spec {
 	return s
 }
 
+// FlatImageRetention is an auto-generated flat version of ImageRetention.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatImageRetention struct {
+	DisplayNamePattern *string           `mapstructure:"display_name_pattern" required:"false" cty:"display_name_pattern" hcl:"display_name_pattern"`
+	Tags               map[string]string `mapstructure:"tags" required:"false" cty:"tags" hcl:"tags"`
+	Keep               *int              `mapstructure:"keep" required:"true" cty:"keep" hcl:"keep"`
+	Action             *string           `mapstructure:"action" required:"false" cty:"action" hcl:"action"`
+	DryRun             *bool             `mapstructure:"dry_run" required:"false" cty:"dry_run" hcl:"dry_run"`
+}
+
+// FlatMapstructure returns a new FlatImageRetention.
+// FlatImageRetention is an auto-generated flat version of ImageRetention.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*ImageRetention) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatImageRetention)
+}
+
+// HCL2Spec returns the hcl spec of a ImageRetention.
+// This spec is used by HCL to read the fields of ImageRetention.
+// The decoded values from this spec will then be applied to a FlatImageRetention.
+func (*FlatImageRetention) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"display_name_pattern": &hcldec.AttrSpec{Name: "display_name_pattern", Type: cty.String, Required: false},
+		"tags":                 &hcldec.AttrSpec{Name: "tags", Type: cty.Map(cty.String), Required: false},
+		"keep":                 &hcldec.AttrSpec{Name: "keep", Type: cty.Number, Required: false},
+		"action":               &hcldec.AttrSpec{Name: "action", Type: cty.String, Required: false},
+		"dry_run":              &hcldec.AttrSpec{Name: "dry_run", Type: cty.Bool, Required: false},
+	}
+	return s
+}
+
+// FlatLaunchOptions is an auto-generated flat version of LaunchOptions.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatLaunchOptions struct {
+	Firmware                        *string `mapstructure:"firmware" required:"false" cty:"firmware" hcl:"firmware"`
+	BootVolumeType                  *string `mapstructure:"boot_volume_type" required:"false" cty:"boot_volume_type" hcl:"boot_volume_type"`
+	RemoteDataVolumeType            *string `mapstructure:"remote_data_volume_type" required:"false" cty:"remote_data_volume_type" hcl:"remote_data_volume_type"`
+	NetworkType                     *string `mapstructure:"network_type" required:"false" cty:"network_type" hcl:"network_type"`
+	IsConsistentVolumeNamingEnabled *bool   `mapstructure:"is_consistent_volume_naming_enabled" required:"false" cty:"is_consistent_volume_naming_enabled" hcl:"is_consistent_volume_naming_enabled"`
+	IsPvEncryptionInTransitEnabled  *bool   `mapstructure:"is_pv_encryption_in_transit_enabled" required:"false" cty:"is_pv_encryption_in_transit_enabled" hcl:"is_pv_encryption_in_transit_enabled"`
+}
+
+// FlatMapstructure returns a new FlatLaunchOptions.
+// FlatLaunchOptions is an auto-generated flat version of LaunchOptions.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*LaunchOptions) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatLaunchOptions)
+}
+
+// HCL2Spec returns the hcl spec of a LaunchOptions.
+// This spec is used by HCL to read the fields of LaunchOptions.
+// The decoded values from this spec will then be applied to a FlatLaunchOptions.
+func (*FlatLaunchOptions) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"firmware":                            &hcldec.AttrSpec{Name: "firmware", Type: cty.String, Required: false},
+		"boot_volume_type":                    &hcldec.AttrSpec{Name: "boot_volume_type", Type: cty.String, Required: false},
+		"remote_data_volume_type":             &hcldec.AttrSpec{Name: "remote_data_volume_type", Type: cty.String, Required: false},
+		"network_type":                        &hcldec.AttrSpec{Name: "network_type", Type: cty.String, Required: false},
+		"is_consistent_volume_naming_enabled": &hcldec.AttrSpec{Name: "is_consistent_volume_naming_enabled", Type: cty.Bool, Required: false},
+		"is_pv_encryption_in_transit_enabled": &hcldec.AttrSpec{Name: "is_pv_encryption_in_transit_enabled", Type: cty.Bool, Required: false},
+	}
+	return s
+}
+
 // FlatListImagesRequest is an auto-generated flat version of ListImagesRequest.
 // Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
 type FlatListImagesRequest struct {
@@ -310,3 +579,57 @@ func (*FlatListImagesRequest) HCL2Spec() map[string]hcldec.Spec {
 	}
 	return s
 }
+
+// FlatPlatformConfig is an auto-generated flat version of PlatformConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatPlatformConfig struct {
+	Type                           *string `mapstructure:"type" required:"true" cty:"type" hcl:"type"`
+	IsSecureBootEnabled            *bool   `mapstructure:"is_secure_boot_enabled" required:"false" cty:"is_secure_boot_enabled" hcl:"is_secure_boot_enabled"`
+	IsMeasuredBootEnabled          *bool   `mapstructure:"is_measured_boot_enabled" required:"false" cty:"is_measured_boot_enabled" hcl:"is_measured_boot_enabled"`
+	IsTrustedPlatformModuleEnabled *bool   `mapstructure:"is_trusted_platform_module_enabled" required:"false" cty:"is_trusted_platform_module_enabled" hcl:"is_trusted_platform_module_enabled"`
+	IsMemoryEncryptionEnabled      *bool   `mapstructure:"is_memory_encryption_enabled" required:"false" cty:"is_memory_encryption_enabled" hcl:"is_memory_encryption_enabled"`
+}
+
+// FlatMapstructure returns a new FlatPlatformConfig.
+// FlatPlatformConfig is an auto-generated flat version of PlatformConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*PlatformConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatPlatformConfig)
+}
+
+// HCL2Spec returns the hcl spec of a PlatformConfig.
+// This spec is used by HCL to read the fields of PlatformConfig.
+// The decoded values from this spec will then be applied to a FlatPlatformConfig.
+func (*FlatPlatformConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"type":                               &hcldec.AttrSpec{Name: "type", Type: cty.String, Required: false},
+		"is_secure_boot_enabled":             &hcldec.AttrSpec{Name: "is_secure_boot_enabled", Type: cty.Bool, Required: false},
+		"is_measured_boot_enabled":           &hcldec.AttrSpec{Name: "is_measured_boot_enabled", Type: cty.Bool, Required: false},
+		"is_trusted_platform_module_enabled": &hcldec.AttrSpec{Name: "is_trusted_platform_module_enabled", Type: cty.Bool, Required: false},
+		"is_memory_encryption_enabled":       &hcldec.AttrSpec{Name: "is_memory_encryption_enabled", Type: cty.Bool, Required: false},
+	}
+	return s
+}
+
+// FlatPreemptibleInstanceConfig is an auto-generated flat version of PreemptibleInstanceConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatPreemptibleInstanceConfig struct {
+	PreserveBootVolume *bool `mapstructure:"preserve_boot_volume" required:"false" cty:"preserve_boot_volume" hcl:"preserve_boot_volume"`
+}
+
+// FlatMapstructure returns a new FlatPreemptibleInstanceConfig.
+// FlatPreemptibleInstanceConfig is an auto-generated flat version of PreemptibleInstanceConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*PreemptibleInstanceConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatPreemptibleInstanceConfig)
+}
+
+// HCL2Spec returns the hcl spec of a PreemptibleInstanceConfig.
+// This spec is used by HCL to read the fields of PreemptibleInstanceConfig.
+// The decoded values from this spec will then be applied to a FlatPreemptibleInstanceConfig.
+func (*FlatPreemptibleInstanceConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"preserve_boot_volume": &hcldec.AttrSpec{Name: "preserve_boot_volume", Type: cty.Bool, Required: false},
+	}
+	return s
+}
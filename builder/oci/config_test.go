@@ -4,14 +4,11 @@
 package oci
 
 import (
-	"crypto/rand"
-	"crypto/rsa"
-	"crypto/x509"
-	"encoding/pem"
 	"io/ioutil"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-ini/ini"
 )
@@ -143,6 +140,696 @@ func TestConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("ArtifactTypeDefault", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if c.ArtifactType != "image" {
+			t.Errorf("Expected default artifact_type 'image', got %q", c.ArtifactType)
+		}
+		if c.BootVolumeBackupType != "FULL" {
+			t.Errorf("Expected default boot_volume_backup_type 'FULL', got %q", c.BootVolumeBackupType)
+		}
+	})
+
+	t.Run("ArtifactTypeInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["artifact_type"] = "snapshot"
+		raw["boot_volume_backup_type"] = "DIFFERENTIAL"
+
+		var c Config
+		errs := c.Prepare(raw)
+
+		expectedErrors := []string{"'artifact_type'", "'boot_volume_backup_type'"}
+
+		if errs == nil {
+			t.Fatalf("Expected errors %q but got none", expectedErrors)
+		}
+
+		s := errs.Error()
+		for _, expected := range expectedErrors {
+			if !strings.Contains(s, expected) {
+				t.Errorf("Expected %q to contain '%s'", s, expected)
+			}
+		}
+	})
+
+	t.Run("CopyToRegions", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["copy_to_regions"] = []string{"us-phoenix-1", "eu-frankfurt-1"}
+		raw["copy_image_bucket_name"] = "packer-images"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+	})
+
+	t.Run("CopyToRegionsInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["copy_to_regions"] = []string{"us-ashburn-1", "us-phoenix-1", "us-phoenix-1"}
+
+		var c Config
+		errs := c.Prepare(raw)
+
+		expectedErrors := []string{
+			"'copy_image_bucket_name'", "build region \"us-ashburn-1\"", "duplicate region \"us-phoenix-1\"",
+		}
+
+		if errs == nil {
+			t.Fatalf("Expected errors %q but got none", expectedErrors)
+		}
+
+		s := errs.Error()
+		for _, expected := range expectedErrors {
+			if !strings.Contains(s, expected) {
+				t.Errorf("Expected %q to contain '%s'", s, expected)
+			}
+		}
+	})
+
+	t.Run("TemporaryNetworkDefaults", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		delete(raw, "subnet_ocid")
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if c.CreateVnicDetails.SubnetId != nil {
+			t.Errorf("Expected no subnet, got %q", *c.CreateVnicDetails.SubnetId)
+		}
+		if c.TemporaryVcnCidrBlock != "10.0.0.0/16" {
+			t.Errorf("Expected default temporary_vcn_cidr_block '10.0.0.0/16', got %q", c.TemporaryVcnCidrBlock)
+		}
+		if len(c.TemporaryNetworkSourceCidrs) != 1 || c.TemporaryNetworkSourceCidrs[0] != "0.0.0.0/0" {
+			t.Errorf("Expected default temporary_network_source_cidrs ['0.0.0.0/0'], got %q", c.TemporaryNetworkSourceCidrs)
+		}
+	})
+
+	t.Run("TemporaryNetworkInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		delete(raw, "subnet_ocid")
+		raw["temporary_vcn_cidr_block"] = "10.0.0.0"
+		raw["temporary_network_source_cidrs"] = []string{"192.168.0.1"}
+
+		var c Config
+		errs := c.Prepare(raw)
+
+		expectedErrors := []string{
+			"'temporary_vcn_cidr_block'", "'temporary_network_source_cidrs'",
+		}
+
+		if errs == nil {
+			t.Fatalf("Expected errors %q but got none", expectedErrors)
+		}
+
+		s := errs.Error()
+		for _, expected := range expectedErrors {
+			if !strings.Contains(s, expected) {
+				t.Errorf("Expected %q to contain '%s'", s, expected)
+			}
+		}
+	})
+
+	t.Run("TemporaryNetworkWithSubnet", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["temporary_vcn_cidr_block"] = "10.0.0.0/16"
+
+		var c Config
+		errs := c.Prepare(raw)
+
+		if errs == nil || !strings.Contains(errs.Error(), "no subnet is specified") {
+			t.Errorf("Expected error about temporary networking options, got %v", errs)
+		}
+	})
+
+	t.Run("PreemptibleInstanceConfig", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["preemptible_instance_config"] = map[string]interface{}{"preserve_boot_volume": true}
+		raw["preemptible_relaunch_attempts"] = 2
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if c.PreemptibleInstanceConfig == nil || !c.PreemptibleInstanceConfig.PreserveBootVolume {
+			t.Errorf("Expected preemptible_instance_config to preserve the boot volume, got %+v", c.PreemptibleInstanceConfig)
+		}
+	})
+
+	t.Run("PreemptibleRelaunchAttemptsWithoutPreemptible", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["preemptible_relaunch_attempts"] = 2
+
+		var c Config
+		errs := c.Prepare(raw)
+
+		if errs == nil || !strings.Contains(errs.Error(), "'preemptible_relaunch_attempts'") {
+			t.Errorf("Expected error about preemptible_relaunch_attempts, got %v", errs)
+		}
+	})
+
+	t.Run("CapacityReservationAndDedicatedVmHost", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["capacity_reservation_ocid"] = "ocid1.capacityreservation.oc1.phx.aaa"
+		raw["dedicated_vm_host_ocid"] = "ocid1.dedicatedvmhost.oc1.phx.aaa"
+		raw["preemptible_instance_config"] = map[string]interface{}{}
+
+		var c Config
+		errs := c.Prepare(raw)
+
+		expectedErrors := []string{
+			"are mutually exclusive",
+			"'capacity_reservation_ocid' can't be used with 'preemptible_instance_config'",
+			"'dedicated_vm_host_ocid' can't be used with 'preemptible_instance_config'",
+		}
+
+		if errs == nil {
+			t.Fatalf("Expected errors %q but got none", expectedErrors)
+		}
+
+		s := errs.Error()
+		for _, expected := range expectedErrors {
+			if !strings.Contains(s, expected) {
+				t.Errorf("Expected %q to contain '%s'", s, expected)
+			}
+		}
+	})
+
+	t.Run("RunCommand", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["communicator"] = "run_command"
+		raw["run_command_bucket_name"] = "staging"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+		if c.Comm.Type != "run_command" {
+			t.Errorf("Expected communicator 'run_command', got %q", c.Comm.Type)
+		}
+		if c.RunCommandTimeout != time.Hour {
+			t.Errorf("Expected default run_command_timeout 1h, got %s", c.RunCommandTimeout)
+		}
+	})
+
+	t.Run("RunCommandInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["communicator"] = "run_command"
+		raw["run_command_timeout"] = "72h"
+		raw["agent_config"] = map[string]interface{}{
+			"plugins_config": []map[string]interface{}{
+				{"name": "Compute Instance Run Command", "desired_state": "DISABLED"},
+			},
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+
+		expectedErrors := []string{
+			"'run_command_bucket_name' must be specified",
+			"'run_command_timeout' must be between 1s and 48h",
+			"the Compute Instance Run Command plugin can't be disabled",
+		}
+
+		if errs == nil {
+			t.Fatalf("Expected errors %q but got none", expectedErrors)
+		}
+
+		s := errs.Error()
+		for _, expected := range expectedErrors {
+			if !strings.Contains(s, expected) {
+				t.Errorf("Expected %q to contain '%s'", s, expected)
+			}
+		}
+	})
+
+	t.Run("NewWinRMPasswordInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["change_initial_password"] = true
+		raw["new_winrm_password"] = "100%secure"
+
+		var c Config
+		errs := c.Prepare(raw)
+
+		expectedErrors := []string{
+			"'change_initial_password' can only be used with the winrm communicator",
+		}
+
+		if errs == nil {
+			t.Fatalf("Expected errors %q but got none", expectedErrors)
+		}
+
+		s := errs.Error()
+		for _, expected := range expectedErrors {
+			if !strings.Contains(s, expected) {
+				t.Errorf("Expected %q to contain '%s'", s, expected)
+			}
+		}
+	})
+
+	t.Run("AgentConfig", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["agent_config"] = map[string]interface{}{
+			"is_monitoring_disabled": true,
+			"plugins_config": []map[string]interface{}{
+				{"name": "Vulnerability Scanning", "desired_state": "ENABLED"},
+				{"name": "OS Management Service Agent", "desired_state": "DISABLED"},
+			},
+		}
+		raw["availability_config"] = map[string]interface{}{
+			"is_live_migration_preferred": true,
+			"recovery_action":             "STOP_INSTANCE",
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+		if len(c.AgentConfig.PluginsConfig) != 2 {
+			t.Errorf("Expected 2 plugins, got %d", len(c.AgentConfig.PluginsConfig))
+		}
+	})
+
+	t.Run("AgentConfigInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["use_private_ip"] = true
+		raw["bastion_ocid"] = "ocid1.bastion.oc1.phx.aaa"
+		raw["agent_config"] = map[string]interface{}{
+			"plugins_config": []map[string]interface{}{
+				{"name": "Bastion", "desired_state": "DISABLED"},
+				{"name": "Bastion", "desired_state": "ENABLED"},
+				{"name": "Run Command", "desired_state": "ON"},
+			},
+		}
+		raw["availability_config"] = map[string]interface{}{
+			"recovery_action": "REBOOT",
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+
+		expectedErrors := []string{
+			"'agent_config.plugins_config' contains plugin \"Bastion\" more than once",
+			"'agent_config.plugins_config[2].name' \"Run Command\" must be one of",
+			"'agent_config.plugins_config[2].desired_state' must be one of ENABLED or DISABLED",
+			"the Bastion plugin can't be disabled",
+			"'availability_config.recovery_action' must be one of RESTORE_INSTANCE or STOP_INSTANCE",
+		}
+
+		if errs == nil {
+			t.Fatalf("Expected errors %q but got none", expectedErrors)
+		}
+
+		s := errs.Error()
+		for _, expected := range expectedErrors {
+			if !strings.Contains(s, expected) {
+				t.Errorf("Expected %q to contain '%s'", s, expected)
+			}
+		}
+	})
+
+	t.Run("ImageRetention", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["image_retention"] = map[string]interface{}{
+			"display_name_pattern": "^packer-",
+			"keep":                 3,
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+		if c.ImageRetention.Action != "delete" {
+			t.Errorf("Expected default action delete, got %q", c.ImageRetention.Action)
+		}
+		if !c.ImageRetention.Matches("packer-123", nil) || c.ImageRetention.Matches("other", nil) {
+			t.Errorf("Unexpected match result for display_name_pattern")
+		}
+	})
+
+	t.Run("ImageRetentionInvalid", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			retention map[string]interface{}
+			expected  []string
+		}{
+			"no selector": {
+				retention: map[string]interface{}{"keep": 0, "action": "archive"},
+				expected: []string{
+					"'image_retention' requires 'display_name_pattern' or 'tags' to select images",
+					"'image_retention.keep' must be at least 1",
+					"'image_retention.action' must be one of delete or deprecate",
+				},
+			},
+			"bad pattern": {
+				retention: map[string]interface{}{"display_name_pattern": "packer-(", "keep": 1},
+				expected:  []string{"'image_retention.display_name_pattern' is invalid"},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				raw := testConfig(cfgFile)
+				raw["image_retention"] = tc.retention
+
+				var c Config
+				errs := c.Prepare(raw)
+				if errs == nil {
+					t.Fatalf("Expected errors %q but got none", tc.expected)
+				}
+
+				s := errs.Error()
+				for _, expected := range tc.expected {
+					if !strings.Contains(s, expected) {
+						t.Errorf("Expected %q to contain '%s'", s, expected)
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("CapacityFallbackInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["availability_domains"] = []string{"aaaa:PHX-AD-3"}
+		raw["fault_domains"] = []string{""}
+		raw["fallback_shapes"] = []map[string]interface{}{
+			{},
+			{"shape": "VM.Standard.E4.Flex"},
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+
+		expectedErrors := []string{
+			"'availability_domains' must not contain empty values or 'availability_domain'",
+			"'fault_domains' must not contain empty values",
+			"'fallback_shapes[0].shape' must be specified",
+			"'fallback_shapes[1].shape_config.ocpus' must be specified when using flexible shapes",
+		}
+
+		if errs == nil {
+			t.Fatalf("Expected errors %q but got none", expectedErrors)
+		}
+
+		s := errs.Error()
+		for _, expected := range expectedErrors {
+			if !strings.Contains(s, expected) {
+				t.Errorf("Expected %q to contain '%s'", s, expected)
+			}
+		}
+	})
+
+	t.Run("BootVolumeKmsKey", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["boot_volume_kms_key_ocid"] = "ocid1.key.oc1.iad.aaaa"
+		raw["boot_volume_vpus_per_gb"] = 20
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+	})
+
+	t.Run("BootVolumeKmsKeyVaultOCID", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["boot_volume_kms_key_ocid"] = "ocid1.key.oc1.iad.bbpxxy3zaaeuk.abuwcljt3xl7wtuxbxgmhwcqnpqkk6whvovh2mpsmzzpm7clz6rmt4cwwyrq"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+	})
+
+	t.Run("BootVolumeKmsKeyInvalid", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			key      string
+			vpus     int
+			expected string
+		}{
+			"other region":       {"ocid1.key.oc1.phx.aaaa", 10, "is in region us-phoenix-1, not in the build region us-ashburn-1"},
+			"other vault region": {"ocid1.key.oc1.phx.bbpxxy3zaaeuk.abuwcljt3xl7wtuxbxgmhwcqnpqkk6whvovh2mpsmzzpm7clz6rmt4cwwyrq", 10, "is in region us-phoenix-1"},
+			"not a key":          {"ocid1.vault.oc1.iad.aaaa", 10, "is not a KMS key OCID"},
+			"vpus":               {"ocid1.key.oc1.iad.aaaa", 0, "'boot_volume_vpus_per_gb' must be a multiple of 10 between 10 and 120"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				raw := testConfig(cfgFile)
+				raw["boot_volume_kms_key_ocid"] = tc.key
+				raw["boot_volume_vpus_per_gb"] = tc.vpus
+
+				var c Config
+				errs := c.Prepare(raw)
+				if errs == nil {
+					t.Fatalf("Expected error %q but got none", tc.expected)
+				}
+				if !strings.Contains(errs.Error(), tc.expected) {
+					t.Errorf("Expected %q to contain '%s'", errs.Error(), tc.expected)
+				}
+			})
+		}
+	})
+
+	t.Run("LaunchOptions", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["nic_attachment_type"] = "VFIO"
+		raw["launch_options"] = map[string]interface{}{
+			"firmware":                            "UEFI_64",
+			"boot_volume_type":                    "PARAVIRTUALIZED",
+			"remote_data_volume_type":             "PARAVIRTUALIZED",
+			"network_type":                        "VFIO",
+			"is_consistent_volume_naming_enabled": true,
+			"is_pv_encryption_in_transit_enabled": true,
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+		if !*c.LaunchOptions.IsPvEncryptionInTransitEnabled {
+			t.Errorf("Expected is_pv_encryption_in_transit_enabled to be true")
+		}
+	})
+
+	t.Run("LaunchOptionsInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["nic_attachment_type"] = "E1000"
+		raw["launch_options"] = map[string]interface{}{
+			"firmware":                            "EFI",
+			"boot_volume_type":                    "ISCSI",
+			"remote_data_volume_type":             "NVME",
+			"network_type":                        "VFIO",
+			"is_pv_encryption_in_transit_enabled": true,
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+
+		expectedErrors := []string{
+			"'launch_options.firmware' must be one of",
+			"'launch_options.remote_data_volume_type' must be one of",
+			"requires a PARAVIRTUALIZED 'boot_volume_type'",
+			"'launch_options.network_type' and 'nic_attachment_type' must match",
+		}
+
+		if errs == nil {
+			t.Fatalf("Expected errors %q but got none", expectedErrors)
+		}
+
+		s := errs.Error()
+		for _, expected := range expectedErrors {
+			if !strings.Contains(s, expected) {
+				t.Errorf("Expected %q to contain '%s'", s, expected)
+			}
+		}
+	})
+
+	t.Run("PlatformConfig", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["shape"] = "VM.Standard.E3.Flex"
+		raw["shape_config"] = map[string]interface{}{"ocpus": 1}
+		raw["platform_config"] = map[string]interface{}{
+			"type":                               "AMD_VM",
+			"is_secure_boot_enabled":             true,
+			"is_measured_boot_enabled":           true,
+			"is_trusted_platform_module_enabled": true,
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+	})
+
+	t.Run("PlatformConfigInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["shape"] = "VM.Standard.E3.Flex"
+		raw["shape_config"] = map[string]interface{}{"ocpus": 1}
+		raw["platform_config"] = map[string]interface{}{
+			"type":                         "INTEL_SKYLAKE_BM",
+			"is_measured_boot_enabled":     true,
+			"is_memory_encryption_enabled": true,
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+
+		expectedErrors := []string{
+			"'platform_config.type' INTEL_SKYLAKE_BM can't be used with shape VM.Standard.E3.Flex",
+			"requires 'is_trusted_platform_module_enabled'",
+			"'platform_config.is_memory_encryption_enabled' isn't supported by type INTEL_SKYLAKE_BM",
+		}
+
+		if errs == nil {
+			t.Fatalf("Expected errors %q but got none", expectedErrors)
+		}
+
+		s := errs.Error()
+		for _, expected := range expectedErrors {
+			if !strings.Contains(s, expected) {
+				t.Errorf("Expected %q to contain '%s'", s, expected)
+			}
+		}
+	})
+
+	t.Run("BlockVolumes", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["block_volumes"] = []map[string]interface{}{
+			{},
+			{"size_in_gbs": 1024, "vpus_per_gb": 20, "attachment_type": "iscsi", "device": "/dev/oracleoci/oraclevdc"},
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if c.BlockVolumes[0].SizeInGBs != 50 || c.BlockVolumes[0].AttachmentType != "paravirtualized" {
+			t.Errorf("Expected default block volume of 50 GBs attached as paravirtualized, got %+v", c.BlockVolumes[0])
+		}
+	})
+
+	t.Run("BlockVolumesInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["block_volumes"] = []map[string]interface{}{
+			{"size_in_gbs": 10, "vpus_per_gb": 15, "attachment_type": "emulated", "device": "/dev/sdb"},
+		}
+
+		var c Config
+		errs := c.Prepare(raw)
+
+		expectedErrors := []string{
+			"'block_volumes[0].size_in_gbs'", "'block_volumes[0].vpus_per_gb'",
+			"'block_volumes[0].attachment_type'", "'block_volumes[0].device'",
+		}
+
+		if errs == nil {
+			t.Fatalf("Expected errors %q but got none", expectedErrors)
+		}
+
+		s := errs.Error()
+		for _, expected := range expectedErrors {
+			if !strings.Contains(s, expected) {
+				t.Errorf("Expected %q to contain '%s'", s, expected)
+			}
+		}
+	})
+
+	t.Run("Bastion", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["bastion_ocid"] = "ocid1.bastion.oc1.iad.aaa"
+		raw["use_private_ip"] = true
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if c.BastionSessionType != "MANAGED_SSH" {
+			t.Errorf("Expected default bastion_session_type 'MANAGED_SSH', got %q", c.BastionSessionType)
+		}
+		if c.BastionSessionTTL != 3*time.Hour {
+			t.Errorf("Expected default bastion_session_ttl 3h, got %s", c.BastionSessionTTL)
+		}
+	})
+
+	t.Run("Timeouts", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["image_create_timeout"] = "6h"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs != nil {
+			t.Fatalf("Unexpected error in configuration %+v", errs)
+		}
+
+		if c.InstanceLaunchTimeout != 30*time.Minute {
+			t.Errorf("Expected default instance_launch_timeout 30m, got %s", c.InstanceLaunchTimeout)
+		}
+		if c.ImageCreateTimeout != 6*time.Hour {
+			t.Errorf("Expected image_create_timeout 6h, got %s", c.ImageCreateTimeout)
+		}
+		if c.InstanceTerminateTimeout != 30*time.Minute {
+			t.Errorf("Expected default instance_terminate_timeout 30m, got %s", c.InstanceTerminateTimeout)
+		}
+		if c.ResourceTimeout != 30*time.Minute {
+			t.Errorf("Expected default resource_timeout 30m, got %s", c.ResourceTimeout)
+		}
+	})
+
+	t.Run("TimeoutsNegative", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["instance_launch_timeout"] = "-5m"
+
+		var c Config
+		errs := c.Prepare(raw)
+		if errs == nil || !strings.Contains(errs.Error(), "'instance_launch_timeout'") {
+			t.Fatalf("Expected error for negative instance_launch_timeout, got %v", errs)
+		}
+	})
+
+	t.Run("BastionInvalid", func(t *testing.T) {
+		raw := testConfig(cfgFile)
+		raw["bastion_ocid"] = "ocid1.bastion.oc1.iad.aaa"
+		raw["bastion_session_type"] = "DYNAMIC_PORT_FORWARDING"
+		raw["bastion_session_ttl"] = "5h"
+		raw["ssh_bastion_host"] = "bastion.example.com"
+
+		var c Config
+		errs := c.Prepare(raw)
+
+		expectedErrors := []string{
+			"'bastion_session_type'", "'bastion_session_ttl'", "'ssh_bastion_host'", "'use_private_ip'",
+		}
+
+		if errs == nil {
+			t.Fatalf("Expected errors %q but got none", expectedErrors)
+		}
+
+		s := errs.Error()
+		for _, expected := range expectedErrors {
+			if !strings.Contains(s, expected) {
+				t.Errorf("Expected %q to contain '%s'", s, expected)
+			}
+		}
+	})
+
 	t.Run("NoAccessConfig", func(t *testing.T) {
 		raw := testConfig(cfgFile)
 		raw["access_cfg_file"] = "/tmp/random/access/config/file/should/not/exist"
@@ -225,7 +912,7 @@ func TestConfig(t *testing.T) {
 
 	// Test the correct errors are produced when required template keys are
 	// omitted.
-	requiredKeys := []string{"availability_domain", "base_image_ocid", "shape", "subnet_ocid"}
+	requiredKeys := []string{"availability_domain", "base_image_ocid", "shape"}
 	for _, k := range requiredKeys {
 		t.Run(k+"_required", func(t *testing.T) {
 			raw := testConfig(cfgFile)
@@ -506,7 +1193,8 @@ func writeTestConfig(cfg *ini.File) (*os.File, error) {
 	return confFile, nil
 }
 
-// generateRSAKeyFile generates an RSA key file for use in unit tests.
+// generateRSAKeyFile writes the test API signing key to a file for use in
+// unit tests.
 // NOTE: The caller is responsible for deleting the temporary file.
 func generateRSAKeyFile() (*os.File, error) {
 	// Create temporary file for the key
@@ -515,22 +1203,8 @@ func generateRSAKeyFile() (*os.File, error) {
 		return nil, err
 	}
 
-	// Generate key
-	priv, err := rsa.GenerateKey(rand.Reader, 2014)
-	if err != nil {
-		return nil, err
-	}
-
-	// ASN.1 DER encoded form
-	privDer := x509.MarshalPKCS1PrivateKey(priv)
-	privBlk := pem.Block{
-		Type:    "RSA PRIVATE KEY",
-		Headers: nil,
-		Bytes:   privDer,
-	}
-
 	// Write the key out
-	if _, err := f.Write(pem.EncodeToMemory(&privBlk)); err != nil {
+	if _, err := f.Write([]byte(testAccessConfigKey)); err != nil {
 		return nil, err
 	}
 
@@ -5,18 +5,105 @@ package oci
 
 import (
 	"context"
+	"errors"
+	"io"
+	"time"
 
 	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/oracle/oci-go-sdk/v65/secrets"
 )
 
+// errInstanceTerminated is returned when an instance gets terminated while
+// waiting for it to reach another state, which happens when preemptible
+// capacity is reclaimed.
+var errInstanceTerminated = errors.New("instance was terminated unexpectedly")
+
+// errOutOfCapacity is returned when an instance can't be launched because OCI
+// has no capacity left for the requested shape in the requested placement.
+var errOutOfCapacity = errors.New("out of host capacity")
+
+// TemporaryNetwork holds the OCIDs of the networking resources created for a
+// build that was not given a subnet. Resources that were not created are left
+// empty.
+type TemporaryNetwork struct {
+	VcnID             string
+	InternetGatewayID string
+	RouteTableID      string
+	SecurityListID    string
+	SubnetID          string
+}
+
+// BastionSession holds what the communicator needs to connect through an
+// OCI Bastion session.
+type BastionSession struct {
+	ID       string
+	Host     string
+	Username string
+}
+
+// VolumeAttachment holds a block volume attachment. The iSCSI target is only
+// set for iSCSI attachments, which the instance has to log in to.
+type VolumeAttachment struct {
+	ID   string
+	IQN  string
+	IPv4 string
+	Port int
+}
+
 // Driver interfaces between the builder steps and the OCI SDK.
 type Driver interface {
 	CreateInstance(ctx context.Context, publicKey string) (string, error)
+	GetSourceImage(ctx context.Context) (core.Image, error)
+	GetInstanceSourceImage(ctx context.Context, instanceID string) (core.Image, error)
 	CreateImage(ctx context.Context, id string) (core.Image, error)
 	DeleteImage(ctx context.Context, id string) error
-	GetInstanceIP(ctx context.Context, id string) (string, error)
+	ListImages(ctx context.Context, request core.ListImagesRequest) ([]core.Image, error)
+	ListCustomImages(ctx context.Context, compartmentID string) ([]core.Image, error)
+	UpdateImageFreeformTags(ctx context.Context, id string, tags map[string]string) error
+	ListCustomImagesInRegion(ctx context.Context, region string, compartmentID string) ([]core.Image, error)
+	UpdateImageFreeformTagsInRegion(ctx context.Context, region string, id string, tags map[string]string) error
+	GetInstanceIPs(ctx context.Context, id string) (string, string, error)
+	GetInstanceInitialCredentials(ctx context.Context, id string) (string, string, error)
 	TerminateInstance(ctx context.Context, id string) error
 	WaitForImageCreation(ctx context.Context, id string) error
-	WaitForInstanceState(ctx context.Context, id string, waitStates []string, terminalState string) error
+	GetInstanceState(ctx context.Context, id string) (string, error)
+	GetInstanceBootVolumeID(ctx context.Context, instanceID string) (string, error)
+	GetConsoleHistory(ctx context.Context, instanceID string) (string, error)
+	WaitForInstanceState(ctx context.Context, id string, waitStates []string, terminalState string, timeout time.Duration) error
 	UpdateImageCapabilitySchema(ctx context.Context, imageId string) (core.UpdateComputeImageCapabilitySchemaResponse, error)
+	UpdateImageCapabilitySchemaInRegion(ctx context.Context, region string, imageId string) error
+	GetNamespace(ctx context.Context) (string, error)
+	ExportImage(ctx context.Context, id string, namespace string, bucket string, objectName string, format string) (string, error)
+	CreateObjectReadURI(ctx context.Context, namespace string, bucket string, objectName string, expires time.Time) (string, error)
+	CreateObjectWriteURI(ctx context.Context, namespace string, bucket string, objectName string, expires time.Time) (string, error)
+	DownloadObject(ctx context.Context, namespace string, bucket string, objectName string, w io.Writer) error
+	DeleteObject(ctx context.Context, namespace string, bucket string, objectName string) error
+	DeleteObjectURIs(ctx context.Context, namespace string, bucket string, objectName string) error
+	UploadObject(ctx context.Context, namespace string, bucket string, objectName string, path string) error
+	ImportImage(ctx context.Context, region string, source core.ImageSourceDetails) (core.Image, error)
+	WaitForImageImport(ctx context.Context, region string, id string) error
+	DeleteImageInRegion(ctx context.Context, region string, id string) error
+	ListAvailabilityDomains(ctx context.Context, compartmentID string) ([]identity.AvailabilityDomain, error)
+	GetCompartment(ctx context.Context, id string) (identity.Compartment, error)
+	GetSubnet(ctx context.Context, id string) (core.Subnet, error)
+	GetNetworkSecurityGroup(ctx context.Context, id string) (core.NetworkSecurityGroup, error)
+	ListShapes(ctx context.Context, availabilityDomain string, imageID string) ([]core.Shape, error)
+	ListSubnets(ctx context.Context, compartmentID string, vcnID string) ([]core.Subnet, error)
+	ListNetworkSecurityGroups(ctx context.Context, vcnID string) ([]core.NetworkSecurityGroup, error)
+	GetSecretBundle(ctx context.Context, secretID string, stage string) (secrets.SecretBundle, error)
+	GetSecretBundleByName(ctx context.Context, vaultID string, name string, stage string) (secrets.SecretBundle, error)
+	CreateTemporaryNetwork(ctx context.Context, port int) (TemporaryNetwork, error)
+	DeleteTemporaryNetwork(ctx context.Context, network TemporaryNetwork) error
+	CreateBootVolumeBackup(ctx context.Context, instanceID string) (string, error)
+	DeleteBootVolumeBackup(ctx context.Context, id string) error
+	CreateVolume(ctx context.Context, volume BlockVolume) (string, error)
+	AttachVolume(ctx context.Context, instanceID, volumeID string, volume BlockVolume) (VolumeAttachment, error)
+	DetachVolume(ctx context.Context, attachmentID string) error
+	CreateVolumeBackup(ctx context.Context, volumeID string) (string, error)
+	DeleteVolumeBackup(ctx context.Context, id string) error
+	DeleteVolume(ctx context.Context, volumeID string) error
+	CreateBastionSession(ctx context.Context, instanceID, ip string, port int, publicKey string) (BastionSession, error)
+	DeleteBastionSession(ctx context.Context, id string) error
+	RunInstanceCommand(ctx context.Context, instanceID string, script string, output io.Writer) (int, error)
 }
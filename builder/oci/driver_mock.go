@@ -5,8 +5,15 @@ package oci
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
 
+	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/oracle/oci-go-sdk/v65/secrets"
 )
 
 // driverMock implements the Driver interface and communicates with Oracle
@@ -14,6 +21,10 @@ import (
 type driverMock struct {
 	CreateInstanceID  string
 	CreateInstanceErr error
+	// CreateInstanceCapacityFailures is the number of launches that fail
+	// for lack of capacity before one succeeds.
+	CreateInstanceCapacityFailures int
+	CreateInstancePlacements       []placement
 
 	CreateImageID  string
 	CreateImageErr error
@@ -21,17 +32,159 @@ type driverMock struct {
 	UpdateSchemaID  string
 	UpdateSchemaErr error
 
+	GetSourceImageErr error
+
+	GetInstanceSourceImageID  string
+	GetInstanceSourceImageErr error
+
 	DeleteImageID  string
+	DeleteImageIDs []string
 	DeleteImageErr error
 
-	GetInstanceIPErr error
+	ListImagesRequest core.ListImagesRequest
+	ListImagesImages  []core.Image
+	ListImagesErr     error
+
+	ListCustomImagesCompartmentID string
+	ListCustomImagesImages        []core.Image
+	ListCustomImagesErr           error
+
+	UpdateImageFreeformTagsIDs  []string
+	UpdateImageFreeformTagsTags map[string]map[string]string
+	UpdateImageFreeformTagsErr  error
+
+	ListCustomImagesInRegionImages map[string][]core.Image
+	ListCustomImagesInRegionErr    error
+
+	UpdateImageFreeformTagsInRegionIDs map[string][]string
+	UpdateImageFreeformTagsInRegionErr error
+
+	GetInstanceIPsNoPublicIP bool
+	GetInstanceIPsErr        error
+
+	GetInstanceInitialCredentialsID  string
+	GetInstanceInitialCredentialsErr error
 
 	TerminateInstanceID  string
 	TerminateInstanceErr error
 
 	WaitForImageCreationErr error
 
-	WaitForInstanceStateErr error
+	WaitForInstanceStateErr     error
+	WaitForInstanceStateTimeout time.Duration
+
+	GetInstanceStateState string
+	GetInstanceStateErr   error
+
+	GetInstanceBootVolumeIDErr error
+
+	GetNamespaceErr error
+
+	ExportImageID     string
+	ExportImageObject string
+	ExportImageFormat string
+	ExportImageErr    error
+
+	CreateObjectReadURIObject string
+	CreateObjectReadURIErr    error
+
+	CreateObjectWriteURIObject string
+	CreateObjectWriteURIErr    error
+
+	DownloadObjectName string
+	DownloadObjectData string
+	DownloadObjectErr  error
+
+	DeleteObjectName string
+	DeleteObjectErr  error
+
+	DeleteObjectURIsName string
+	DeleteObjectURIsErr  error
+
+	UploadObjectName string
+	UploadObjectPath string
+	UploadObjectData string
+	UploadObjectErr  error
+
+	GetConsoleHistoryInstanceID string
+	GetConsoleHistoryContent    string
+	GetConsoleHistoryErr        error
+
+	RunInstanceCommandScripts  []string
+	RunInstanceCommandOutput   string
+	RunInstanceCommandExitCode int
+	RunInstanceCommandErr      error
+
+	ImportImageRegions []string
+	ImportImageErr     error
+
+	UpdateSchemaRegions []string
+
+	WaitForImageImportErr error
+
+	DeleteImageInRegionIDs map[string]string
+	DeleteImageInRegionErr error
+
+	ListAvailabilityDomainsErr error
+
+	GetCompartmentErr error
+
+	GetSubnetAvailabilityDomain string
+	GetSubnetErr                error
+
+	GetNetworkSecurityGroupErrs map[string]error
+
+	ListShapesShapes       []core.Shape
+	ListShapesImageShapes  []core.Shape
+	ListShapesDomainShapes map[string][]core.Shape
+	ListShapesErr          error
+
+	ListSubnetsSubnets []core.Subnet
+	ListSubnetsErr     error
+
+	ListNetworkSecurityGroupsGroups []core.NetworkSecurityGroup
+	ListNetworkSecurityGroupsErr    error
+
+	GetSecretBundleErr error
+
+	CreateTemporaryNetworkPort int
+	CreateTemporaryNetworkErr  error
+
+	DeleteTemporaryNetworkVcnID string
+	DeleteTemporaryNetworkErr   error
+
+	CreateBootVolumeBackupInstanceID string
+	CreateBootVolumeBackupErr        error
+
+	DeleteBootVolumeBackupID  string
+	DeleteBootVolumeBackupErr error
+
+	CreateVolumeSizes []int64
+	CreateVolumeErr   error
+
+	AttachVolumeInstanceID string
+	AttachVolumeIDs        []string
+	AttachVolumeErr        error
+
+	DetachVolumeIDs []string
+	DetachVolumeErr error
+
+	CreateVolumeBackupIDs []string
+	CreateVolumeBackupErr error
+
+	DeleteVolumeBackupIDs []string
+	DeleteVolumeBackupErr error
+
+	DeleteVolumeIDs []string
+	DeleteVolumeErr error
+
+	CreateBastionSessionInstanceID string
+	CreateBastionSessionIP         string
+	CreateBastionSessionPort       int
+	CreateBastionSessionErr        error
+
+	DeleteBastionSessionID  string
+	DeleteBastionSessionErr error
 
 	cfg                                                   *Config
 	CapturedInstanceOptionsAreLegacyImdsEndpointsDisabled *bool
@@ -43,6 +196,19 @@ func (d *driverMock) CreateInstance(ctx context.Context, publicKey string) (stri
 		return "", d.CreateInstanceErr
 	}
 
+	if d.cfg != nil {
+		d.CreateInstancePlacements = append(d.CreateInstancePlacements, placement{
+			availabilityDomain: d.cfg.AvailabilityDomain,
+			faultDomain:        d.cfg.faultDomain,
+			shape:              d.cfg.Shape,
+			shapeConfig:        d.cfg.ShapeConfig,
+		})
+	}
+	if d.CreateInstanceCapacityFailures > 0 {
+		d.CreateInstanceCapacityFailures--
+		return "", errOutOfCapacity
+	}
+
 	d.CreateInstanceID = "ocid1..."
 	if d.cfg != nil {
 		// Capture the value from the Config struct that the step is expected to use.
@@ -54,6 +220,35 @@ func (d *driverMock) CreateInstance(ctx context.Context, publicKey string) (stri
 	return d.CreateInstanceID, nil
 }
 
+// GetSourceImage returns a mocked base image.
+func (d *driverMock) GetSourceImage(ctx context.Context) (core.Image, error) {
+	if d.GetSourceImageErr != nil {
+		return core.Image{}, d.GetSourceImageErr
+	}
+
+	id := "ocid1.image.oc1.iad.source"
+	if d.cfg != nil && d.cfg.BaseImageID != "" {
+		id = d.cfg.BaseImageID
+	}
+
+	return core.Image{
+		Id:                     &id,
+		DisplayName:            common.String("Oracle-Linux-8.10-2025.01.31-0"),
+		OperatingSystem:        common.String("Oracle Linux"),
+		OperatingSystemVersion: common.String("8"),
+	}, nil
+}
+
+// GetInstanceSourceImage returns the mocked base image for an instance.
+func (d *driverMock) GetInstanceSourceImage(ctx context.Context, instanceID string) (core.Image, error) {
+	if d.GetInstanceSourceImageErr != nil {
+		return core.Image{}, d.GetInstanceSourceImageErr
+	}
+
+	d.GetInstanceSourceImageID = instanceID
+	return d.GetSourceImage(ctx)
+}
+
 // CreateImage creates a new custom image.
 func (d *driverMock) CreateImage(ctx context.Context, id string) (core.Image, error) {
 	if d.CreateImageErr != nil {
@@ -79,19 +274,93 @@ func (d *driverMock) DeleteImage(ctx context.Context, id string) error {
 	}
 
 	d.DeleteImageID = id
+	d.DeleteImageIDs = append(d.DeleteImageIDs, id)
+
+	return nil
+}
+
+// ListImages returns the mocked images.
+func (d *driverMock) ListImages(ctx context.Context, request core.ListImagesRequest) ([]core.Image, error) {
+	if d.ListImagesErr != nil {
+		return nil, d.ListImagesErr
+	}
+
+	d.ListImagesRequest = request
+
+	return d.ListImagesImages, nil
+}
+
+// ListCustomImages returns the mocked custom images of a compartment.
+func (d *driverMock) ListCustomImages(ctx context.Context, compartmentID string) ([]core.Image, error) {
+	if d.ListCustomImagesErr != nil {
+		return nil, d.ListCustomImagesErr
+	}
+
+	d.ListCustomImagesCompartmentID = compartmentID
+
+	return d.ListCustomImagesImages, nil
+}
+
+// ListCustomImagesInRegion returns the mocked custom images of a region.
+func (d *driverMock) ListCustomImagesInRegion(ctx context.Context, region string, compartmentID string) ([]core.Image, error) {
+	if d.ListCustomImagesInRegionErr != nil {
+		return nil, d.ListCustomImagesInRegionErr
+	}
+
+	return d.ListCustomImagesInRegionImages[region], nil
+}
+
+// UpdateImageFreeformTagsInRegion mocks replacing the freeform tags of an
+// image in the given region.
+func (d *driverMock) UpdateImageFreeformTagsInRegion(ctx context.Context, region string, id string, tags map[string]string) error {
+	if d.UpdateImageFreeformTagsInRegionErr != nil {
+		return d.UpdateImageFreeformTagsInRegionErr
+	}
+
+	if d.UpdateImageFreeformTagsInRegionIDs == nil {
+		d.UpdateImageFreeformTagsInRegionIDs = make(map[string][]string)
+	}
+	d.UpdateImageFreeformTagsInRegionIDs[region] = append(d.UpdateImageFreeformTagsInRegionIDs[region], id)
+
+	return nil
+}
+
+// UpdateImageFreeformTags mocks replacing the freeform tags of an image.
+func (d *driverMock) UpdateImageFreeformTags(ctx context.Context, id string, tags map[string]string) error {
+	if d.UpdateImageFreeformTagsErr != nil {
+		return d.UpdateImageFreeformTagsErr
+	}
+
+	d.UpdateImageFreeformTagsIDs = append(d.UpdateImageFreeformTagsIDs, id)
+	if d.UpdateImageFreeformTagsTags == nil {
+		d.UpdateImageFreeformTagsTags = make(map[string]map[string]string)
+	}
+	d.UpdateImageFreeformTagsTags[id] = tags
 
 	return nil
 }
 
-// GetInstanceIP returns the public or private IP corresponding to the given instance id.
-func (d *driverMock) GetInstanceIP(ctx context.Context, id string) (string, error) {
-	if d.GetInstanceIPErr != nil {
-		return "", d.GetInstanceIPErr
+// GetInstanceInitialCredentials returns the generated Windows credentials of
+// the given instance.
+func (d *driverMock) GetInstanceInitialCredentials(ctx context.Context, id string) (string, string, error) {
+	if d.GetInstanceInitialCredentialsErr != nil {
+		return "", "", d.GetInstanceInitialCredentialsErr
+	}
+
+	d.GetInstanceInitialCredentialsID = id
+
+	return "opc", "initial-password", nil
+}
+
+// GetInstanceIPs returns the private and public IP of the given instance.
+func (d *driverMock) GetInstanceIPs(ctx context.Context, id string) (string, string, error) {
+	if d.GetInstanceIPsErr != nil {
+		return "", "", d.GetInstanceIPsErr
 	}
-	if d.cfg.UsePrivateIP {
-		return "private_ip", nil
+	if d.GetInstanceIPsNoPublicIP {
+		return "private_ip", "", nil
 	}
-	return "ip", nil
+	return "private_ip", "ip", nil
 }
 
 // TerminateInstance terminates a compute instance.
@@ -111,8 +380,443 @@ func (d *driverMock) WaitForImageCreation(ctx context.Context, id string) error
 	return d.WaitForImageCreationErr
 }
 
+// GetInstanceState returns the mocked lifecycle state of an instance.
+func (d *driverMock) GetInstanceState(ctx context.Context, id string) (string, error) {
+	if d.GetInstanceStateErr != nil {
+		return "", d.GetInstanceStateErr
+	}
+
+	if d.GetInstanceStateState == "" {
+		return "RUNNING", nil
+	}
+
+	return d.GetInstanceStateState, nil
+}
+
+// GetInstanceBootVolumeID mocks looking up the boot volume of an instance.
+func (d *driverMock) GetInstanceBootVolumeID(ctx context.Context, instanceID string) (string, error) {
+	if d.GetInstanceBootVolumeIDErr != nil {
+		return "", d.GetInstanceBootVolumeIDErr
+	}
+
+	return "ocid1.bootvolume...", nil
+}
+
 // WaitForInstanceState waits for an instance to reach the a given terminal
 // state.
-func (d *driverMock) WaitForInstanceState(ctx context.Context, id string, waitStates []string, terminalState string) error {
+func (d *driverMock) WaitForInstanceState(ctx context.Context, id string, waitStates []string, terminalState string, timeout time.Duration) error {
+	d.WaitForInstanceStateTimeout = timeout
 	return d.WaitForInstanceStateErr
 }
+
+// GetNamespace mocks looking up the Object Storage namespace.
+func (d *driverMock) GetNamespace(ctx context.Context) (string, error) {
+	if d.GetNamespaceErr != nil {
+		return "", d.GetNamespaceErr
+	}
+
+	return "namespace", nil
+}
+
+// ExportImage mocks exporting a custom image to Object Storage.
+func (d *driverMock) ExportImage(ctx context.Context, id string, namespace string, bucket string, objectName string, format string) (string, error) {
+	if d.ExportImageErr != nil {
+		return "", d.ExportImageErr
+	}
+
+	d.ExportImageID = id
+	d.ExportImageObject = objectName
+	d.ExportImageFormat = format
+
+	return "https://objectstorage/n/" + namespace + "/b/" + bucket + "/o/" + objectName, nil
+}
+
+// CreateObjectReadURI mocks creating a pre-authenticated request.
+func (d *driverMock) CreateObjectReadURI(ctx context.Context, namespace string, bucket string, objectName string, expires time.Time) (string, error) {
+	if d.CreateObjectReadURIErr != nil {
+		return "", d.CreateObjectReadURIErr
+	}
+
+	d.CreateObjectReadURIObject = objectName
+
+	return "https://objectstorage/p/par/n/" + namespace + "/b/" + bucket + "/o/" + objectName, nil
+}
+
+// CreateObjectWriteURI mocks creating a pre-authenticated request.
+func (d *driverMock) CreateObjectWriteURI(ctx context.Context, namespace string, bucket string, objectName string, expires time.Time) (string, error) {
+	if d.CreateObjectWriteURIErr != nil {
+		return "", d.CreateObjectWriteURIErr
+	}
+
+	d.CreateObjectWriteURIObject = objectName
+
+	return "https://objectstorage/p/par/n/" + namespace + "/b/" + bucket + "/o/" + objectName, nil
+}
+
+// DownloadObject mocks downloading an Object Storage object.
+func (d *driverMock) DownloadObject(ctx context.Context, namespace string, bucket string, objectName string, w io.Writer) error {
+	if d.DownloadObjectErr != nil {
+		return d.DownloadObjectErr
+	}
+
+	d.DownloadObjectName = objectName
+
+	_, err := io.WriteString(w, d.DownloadObjectData)
+	return err
+}
+
+// DeleteObject mocks deleting an Object Storage object.
+func (d *driverMock) DeleteObject(ctx context.Context, namespace string, bucket string, objectName string) error {
+	if d.DeleteObjectErr != nil {
+		return d.DeleteObjectErr
+	}
+
+	d.DeleteObjectName = objectName
+
+	return nil
+}
+
+// DeleteObjectURIs mocks deleting the pre-authenticated requests of an
+// Object Storage object.
+func (d *driverMock) DeleteObjectURIs(ctx context.Context, namespace string, bucket string, objectName string) error {
+	if d.DeleteObjectURIsErr != nil {
+		return d.DeleteObjectURIsErr
+	}
+
+	d.DeleteObjectURIsName = objectName
+
+	return nil
+}
+
+// UploadObject mocks uploading a file to Object Storage.
+func (d *driverMock) UploadObject(ctx context.Context, namespace string, bucket string, objectName string, path string) error {
+	if d.UploadObjectErr != nil {
+		return d.UploadObjectErr
+	}
+
+	d.UploadObjectName = objectName
+	d.UploadObjectPath = path
+
+	data, err := os.ReadFile(path)
+	d.UploadObjectData = string(data)
+
+	return err
+}
+
+// GetConsoleHistory mocks capturing the serial console history.
+func (d *driverMock) GetConsoleHistory(ctx context.Context, instanceID string) (string, error) {
+	if d.GetConsoleHistoryErr != nil {
+		return "", d.GetConsoleHistoryErr
+	}
+
+	d.GetConsoleHistoryInstanceID = instanceID
+
+	return d.GetConsoleHistoryContent, nil
+}
+
+// RunInstanceCommand mocks running a script through the Run Command plugin.
+func (d *driverMock) RunInstanceCommand(ctx context.Context, instanceID string, script string, output io.Writer) (int, error) {
+	d.RunInstanceCommandScripts = append(d.RunInstanceCommandScripts, script)
+
+	if d.RunInstanceCommandErr != nil {
+		return 0, d.RunInstanceCommandErr
+	}
+
+	if _, err := io.WriteString(output, d.RunInstanceCommandOutput); err != nil {
+		return 0, err
+	}
+
+	return d.RunInstanceCommandExitCode, nil
+}
+
+// UpdateImageCapabilitySchemaInRegion mocks updating the schema of an image
+// copy.
+func (d *driverMock) UpdateImageCapabilitySchemaInRegion(ctx context.Context, region string, imageId string) error {
+	if d.UpdateSchemaErr != nil {
+		return d.UpdateSchemaErr
+	}
+	d.UpdateSchemaRegions = append(d.UpdateSchemaRegions, region)
+	return nil
+}
+
+// ImportImage mocks importing an image file into a region.
+func (d *driverMock) ImportImage(ctx context.Context, region string, source core.ImageSourceDetails) (core.Image, error) {
+	if d.ImportImageErr != nil {
+		return core.Image{}, d.ImportImageErr
+	}
+
+	d.ImportImageRegions = append(d.ImportImageRegions, region)
+	id := "ocid1.image.oc1." + region
+
+	return core.Image{Id: &id}, nil
+}
+
+// WaitForImageImport mocks waiting for an image import to finish.
+func (d *driverMock) WaitForImageImport(ctx context.Context, region string, id string) error {
+	return d.WaitForImageImportErr
+}
+
+// DeleteImageInRegion mocks deleting a custom image in the given region.
+func (d *driverMock) DeleteImageInRegion(ctx context.Context, region string, id string) error {
+	if d.DeleteImageInRegionErr != nil {
+		return d.DeleteImageInRegionErr
+	}
+
+	if d.DeleteImageInRegionIDs == nil {
+		d.DeleteImageInRegionIDs = make(map[string]string)
+	}
+	d.DeleteImageInRegionIDs[region] = id
+
+	return nil
+}
+
+// ListAvailabilityDomains returns the mocked availability domains.
+func (d *driverMock) ListAvailabilityDomains(ctx context.Context, compartmentID string) ([]identity.AvailabilityDomain, error) {
+	if d.ListAvailabilityDomainsErr != nil {
+		return nil, d.ListAvailabilityDomainsErr
+	}
+
+	return []identity.AvailabilityDomain{
+		{Name: common.String("aaaa:US-ASHBURN-AD-1"), Id: common.String("ocid1.availabilitydomain.oc1..ad1")},
+		{Name: common.String("aaaa:US-ASHBURN-AD-2"), Id: common.String("ocid1.availabilitydomain.oc1..ad2")},
+		{Name: common.String("aaaa:US-ASHBURN-AD-3"), Id: common.String("ocid1.availabilitydomain.oc1..ad3")},
+	}, nil
+}
+
+// GetCompartment mocks looking up a compartment.
+func (d *driverMock) GetCompartment(ctx context.Context, id string) (identity.Compartment, error) {
+	if d.GetCompartmentErr != nil {
+		return identity.Compartment{}, d.GetCompartmentErr
+	}
+
+	return identity.Compartment{Id: &id}, nil
+}
+
+// GetSubnet mocks looking up a subnet, which is regional unless
+// GetSubnetAvailabilityDomain is set.
+func (d *driverMock) GetSubnet(ctx context.Context, id string) (core.Subnet, error) {
+	if d.GetSubnetErr != nil {
+		return core.Subnet{}, d.GetSubnetErr
+	}
+
+	subnet := core.Subnet{Id: &id}
+	if d.GetSubnetAvailabilityDomain != "" {
+		subnet.AvailabilityDomain = &d.GetSubnetAvailabilityDomain
+	}
+
+	return subnet, nil
+}
+
+// GetNetworkSecurityGroup mocks looking up a network security group.
+func (d *driverMock) GetNetworkSecurityGroup(ctx context.Context, id string) (core.NetworkSecurityGroup, error) {
+	if err := d.GetNetworkSecurityGroupErrs[id]; err != nil {
+		return core.NetworkSecurityGroup{}, err
+	}
+
+	return core.NetworkSecurityGroup{Id: &id}, nil
+}
+
+// ListShapes returns the mocked shapes, by default only the configured shape.
+// Shapes set for an availability domain are returned whether or not an image
+// is given.
+func (d *driverMock) ListShapes(ctx context.Context, availabilityDomain string, imageID string) ([]core.Shape, error) {
+	if d.ListShapesErr != nil {
+		return nil, d.ListShapesErr
+	}
+
+	if shapes, ok := d.ListShapesDomainShapes[availabilityDomain]; ok {
+		return shapes, nil
+	}
+	if imageID != "" && d.ListShapesImageShapes != nil {
+		return d.ListShapesImageShapes, nil
+	}
+	if d.ListShapesShapes != nil {
+		return d.ListShapesShapes, nil
+	}
+
+	return []core.Shape{{Shape: &d.cfg.Shape}}, nil
+}
+
+// ListSubnets returns the mocked subnets.
+func (d *driverMock) ListSubnets(ctx context.Context, compartmentID string, vcnID string) ([]core.Subnet, error) {
+	if d.ListSubnetsErr != nil {
+		return nil, d.ListSubnetsErr
+	}
+
+	return d.ListSubnetsSubnets, nil
+}
+
+// ListNetworkSecurityGroups returns the mocked network security groups.
+func (d *driverMock) ListNetworkSecurityGroups(ctx context.Context, vcnID string) ([]core.NetworkSecurityGroup, error) {
+	if d.ListNetworkSecurityGroupsErr != nil {
+		return nil, d.ListNetworkSecurityGroupsErr
+	}
+
+	return d.ListNetworkSecurityGroupsGroups, nil
+}
+
+// GetSecretBundle mocks reading a Vault secret.
+func (d *driverMock) GetSecretBundle(ctx context.Context, secretID string, stage string) (secrets.SecretBundle, error) {
+	if d.GetSecretBundleErr != nil {
+		return secrets.SecretBundle{}, d.GetSecretBundleErr
+	}
+
+	return secrets.SecretBundle{SecretId: &secretID, VersionNumber: common.Int64(1)}, nil
+}
+
+// GetSecretBundleByName mocks reading a Vault secret by name.
+func (d *driverMock) GetSecretBundleByName(ctx context.Context, vaultID string, name string, stage string) (secrets.SecretBundle, error) {
+	return d.GetSecretBundle(ctx, "ocid1.vaultsecret.oc1..mock", stage)
+}
+
+// CreateTemporaryNetwork mocks creating temporary networking resources.
+func (d *driverMock) CreateTemporaryNetwork(ctx context.Context, port int) (TemporaryNetwork, error) {
+	if d.CreateTemporaryNetworkErr != nil {
+		return TemporaryNetwork{VcnID: "ocid1.vcn..."}, d.CreateTemporaryNetworkErr
+	}
+
+	d.CreateTemporaryNetworkPort = port
+
+	return TemporaryNetwork{
+		VcnID:             "ocid1.vcn...",
+		InternetGatewayID: "ocid1.internetgateway...",
+		RouteTableID:      "ocid1.routetable...",
+		SecurityListID:    "ocid1.securitylist...",
+		SubnetID:          "ocid1.subnet...",
+	}, nil
+}
+
+// DeleteTemporaryNetwork mocks deleting temporary networking resources.
+func (d *driverMock) DeleteTemporaryNetwork(ctx context.Context, network TemporaryNetwork) error {
+	if d.DeleteTemporaryNetworkErr != nil {
+		return d.DeleteTemporaryNetworkErr
+	}
+
+	d.DeleteTemporaryNetworkVcnID = network.VcnID
+
+	return nil
+}
+
+// CreateBootVolumeBackup mocks backing up the boot volume of an instance.
+func (d *driverMock) CreateBootVolumeBackup(ctx context.Context, instanceID string) (string, error) {
+	if d.CreateBootVolumeBackupErr != nil {
+		return "", d.CreateBootVolumeBackupErr
+	}
+
+	d.CreateBootVolumeBackupInstanceID = instanceID
+
+	return "ocid1.bootvolumebackup...", nil
+}
+
+// DeleteBootVolumeBackup mocks deleting a boot volume backup.
+func (d *driverMock) DeleteBootVolumeBackup(ctx context.Context, id string) error {
+	if d.DeleteBootVolumeBackupErr != nil {
+		return d.DeleteBootVolumeBackupErr
+	}
+
+	d.DeleteBootVolumeBackupID = id
+
+	return nil
+}
+
+// CreateVolume mocks creating a block volume.
+func (d *driverMock) CreateVolume(ctx context.Context, volume BlockVolume) (string, error) {
+	if d.CreateVolumeErr != nil {
+		return "", d.CreateVolumeErr
+	}
+
+	d.CreateVolumeSizes = append(d.CreateVolumeSizes, volume.SizeInGBs)
+
+	return fmt.Sprintf("ocid1.volume...%d", len(d.CreateVolumeSizes)), nil
+}
+
+// AttachVolume mocks attaching a block volume.
+func (d *driverMock) AttachVolume(ctx context.Context, instanceID, volumeID string, volume BlockVolume) (VolumeAttachment, error) {
+	if d.AttachVolumeErr != nil {
+		return VolumeAttachment{}, d.AttachVolumeErr
+	}
+
+	d.AttachVolumeInstanceID = instanceID
+	d.AttachVolumeIDs = append(d.AttachVolumeIDs, volumeID)
+
+	attachment := VolumeAttachment{ID: fmt.Sprintf("ocid1.volumeattachment...%d", len(d.AttachVolumeIDs))}
+	if volume.AttachmentType == "iscsi" {
+		attachment.IQN = fmt.Sprintf("iqn.2015-12.com.oracleiaas:%d", len(d.AttachVolumeIDs))
+		attachment.IPv4 = "169.254.2.2"
+		attachment.Port = 3260
+	}
+
+	return attachment, nil
+}
+
+// DetachVolume mocks detaching a block volume.
+func (d *driverMock) DetachVolume(ctx context.Context, attachmentID string) error {
+	if d.DetachVolumeErr != nil {
+		return d.DetachVolumeErr
+	}
+
+	d.DetachVolumeIDs = append(d.DetachVolumeIDs, attachmentID)
+
+	return nil
+}
+
+// CreateVolumeBackup mocks creating a block volume backup.
+func (d *driverMock) CreateVolumeBackup(ctx context.Context, volumeID string) (string, error) {
+	if d.CreateVolumeBackupErr != nil {
+		return "", d.CreateVolumeBackupErr
+	}
+
+	d.CreateVolumeBackupIDs = append(d.CreateVolumeBackupIDs, volumeID)
+
+	return fmt.Sprintf("ocid1.volumebackup...%d", len(d.CreateVolumeBackupIDs)), nil
+}
+
+// DeleteVolumeBackup mocks deleting a block volume backup.
+func (d *driverMock) DeleteVolumeBackup(ctx context.Context, id string) error {
+	if d.DeleteVolumeBackupErr != nil {
+		return d.DeleteVolumeBackupErr
+	}
+
+	d.DeleteVolumeBackupIDs = append(d.DeleteVolumeBackupIDs, id)
+
+	return nil
+}
+
+// DeleteVolume mocks deleting a block volume.
+func (d *driverMock) DeleteVolume(ctx context.Context, volumeID string) error {
+	if d.DeleteVolumeErr != nil {
+		return d.DeleteVolumeErr
+	}
+
+	d.DeleteVolumeIDs = append(d.DeleteVolumeIDs, volumeID)
+
+	return nil
+}
+
+// CreateBastionSession mocks creating a bastion session.
+func (d *driverMock) CreateBastionSession(ctx context.Context, instanceID, ip string, port int, publicKey string) (BastionSession, error) {
+	if d.CreateBastionSessionErr != nil {
+		return BastionSession{}, d.CreateBastionSessionErr
+	}
+
+	d.CreateBastionSessionInstanceID = instanceID
+	d.CreateBastionSessionIP = ip
+	d.CreateBastionSessionPort = port
+
+	return BastionSession{
+		ID:       "ocid1.bastionsession...",
+		Host:     "host.bastion.us-ashburn-1.oci.oraclecloud.com",
+		Username: "ocid1.bastionsession...",
+	}, nil
+}
+
+// DeleteBastionSession mocks deleting a bastion session.
+func (d *driverMock) DeleteBastionSession(ctx context.Context, id string) error {
+	if d.DeleteBastionSessionErr != nil {
+		return d.DeleteBastionSessionErr
+	}
+
+	d.DeleteBastionSessionID = id
+
+	return nil
+}
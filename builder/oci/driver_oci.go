@@ -7,24 +7,42 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"log"
 	"math"
 	"math/rand"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/packer-plugin-sdk/uuid"
+	"github.com/oracle/oci-go-sdk/v65/bastion"
 	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/computeinstanceagent"
 	core "github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage/transfer"
+	"github.com/oracle/oci-go-sdk/v65/secrets"
+	"github.com/oracle/oci-go-sdk/v65/workrequests"
 )
 
 // driverOCI implements the Driver interface and communicates with Oracle
 // OCI.
 type driverOCI struct {
-	computeClient core.ComputeClient
-	vcnClient     core.VirtualNetworkClient
-	cfg           *Config
+	computeClient       core.ComputeClient
+	vcnClient           core.VirtualNetworkClient
+	objectStorageClient objectstorage.ObjectStorageClient
+	workRequestClient   workrequests.WorkRequestClient
+	blockstorageClient  core.BlockstorageClient
+	bastionClient       bastion.BastionClient
+	instanceAgentClient computeinstanceagent.ComputeInstanceAgentClient
+	identityClient      identity.IdentityClient
+	secretsClient       secrets.SecretsClient
+	cfg                 *Config
 }
 
 var retryPolicy = &common.RetryPolicy{
@@ -32,6 +50,11 @@ var retryPolicy = &common.RetryPolicy{
 	ShouldRetryOperation: func(res common.OCIOperationResponse) bool {
 		var e common.ServiceError
 		if errors.As(res.Error, &e) {
+			// Capacity doesn't come back within the retry window, fall back
+			// to another placement instead.
+			if isOutOfCapacity(e) {
+				return false
+			}
 			switch e.GetHTTPStatusCode() {
 			case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusServiceUnavailable:
 				return true
@@ -64,10 +87,52 @@ func NewDriverOCI(cfg *Config) (Driver, error) {
 		return nil, err
 	}
 
+	objectStorageClient, err := objectstorage.NewObjectStorageClientWithConfigurationProvider(cfg.configProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	workRequestClient, err := workrequests.NewWorkRequestClientWithConfigurationProvider(cfg.configProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	blockstorageClient, err := core.NewBlockstorageClientWithConfigurationProvider(cfg.configProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	bastionClient, err := bastion.NewBastionClientWithConfigurationProvider(cfg.configProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	instanceAgentClient, err := computeinstanceagent.NewComputeInstanceAgentClientWithConfigurationProvider(cfg.configProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	identityClient, err := identity.NewIdentityClientWithConfigurationProvider(cfg.configProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	secretsClient, err := secrets.NewSecretsClientWithConfigurationProvider(cfg.configProvider)
+	if err != nil {
+		return nil, err
+	}
+
 	return &driverOCI{
-		computeClient: coreClient,
-		vcnClient:     vcnClient,
-		cfg:           cfg,
+		computeClient:       coreClient,
+		vcnClient:           vcnClient,
+		objectStorageClient: objectStorageClient,
+		workRequestClient:   workRequestClient,
+		blockstorageClient:  blockstorageClient,
+		bastionClient:       bastionClient,
+		instanceAgentClient: instanceAgentClient,
+		identityClient:      identityClient,
+		secretsClient:       secretsClient,
+		cfg:                 cfg,
 	}, nil
 }
 
@@ -99,59 +164,13 @@ func (d *driverOCI) CreateInstance(ctx context.Context, publicKey string) (strin
 	}
 
 	// Determine base image ID
-	var imageId *string
-	if d.cfg.BaseImageID != "" {
-		imageId = &d.cfg.BaseImageID
-	} else {
-		request := core.ListImagesRequest{
-			CompartmentId:          d.cfg.BaseImageFilter.CompartmentId,
-			DisplayName:            d.cfg.BaseImageFilter.DisplayName,
-			OperatingSystem:        d.cfg.BaseImageFilter.OperatingSystem,
-			OperatingSystemVersion: d.cfg.BaseImageFilter.OperatingSystemVersion,
-			Shape:                  d.cfg.BaseImageFilter.Shape,
-			LifecycleState:         "AVAILABLE",
-			SortBy:                 "TIMECREATED",
-			SortOrder:              "DESC",
-			RequestMetadata:        requestMetadata,
-			Page:                   common.String(""),
-		}
-
-		for request.Page != nil && imageId == nil {
-			// Pull images and determine which image ID to use, if BaseImageId not specified
-			response, err := d.computeClient.ListImages(ctx, request)
-			if err != nil {
-				return "", err
-			}
-
-			if len(response.Items) == 0 && response.OpcNextPage == nil {
-				return "", errors.New("base_image_filter returned no images")
-			}
-
-			if d.cfg.BaseImageFilter.DisplayNameSearch != nil {
-				// Return most recent image that matches regex
-				imageNameRegex, err := regexp.Compile(*d.cfg.BaseImageFilter.DisplayNameSearch)
-				if err != nil {
-					return "", err
-				}
-				for _, image := range response.Items {
-					if imageNameRegex.MatchString(*image.DisplayName) {
-						imageId = image.Id
-						break
-					}
-				}
-
-				if imageId == nil && response.OpcNextPage == nil {
-					return "", errors.New("no image matched display_name_search criteria")
-				}
-			} else {
-				// If no regex provided, simply return most recent image pulled
-				if len(response.Items) > 0 {
-					imageId = response.Items[0].Id
-				}
-			}
-
-			request.Page = response.OpcNextPage
+	imageId := &d.cfg.BaseImageID
+	if d.cfg.BaseImageID == "" {
+		image, err := d.GetSourceImage(ctx)
+		if err != nil {
+			return "", err
 		}
+		imageId = image.Id
 	}
 
 	// Create Source details which will be used to Launch Instance
@@ -160,6 +179,10 @@ func (d *driverOCI) CreateInstance(ctx context.Context, publicKey string) (strin
 	if d.cfg.BootVolumeSizeInGBs != 0 {
 		InstanceSourceDetails.BootVolumeSizeInGBs = &d.cfg.BootVolumeSizeInGBs
 	}
+	if d.cfg.BootVolumeKmsKeyID != "" {
+		InstanceSourceDetails.KmsKeyId = &d.cfg.BootVolumeKmsKeyID
+	}
+	InstanceSourceDetails.BootVolumeVpusPerGB = d.cfg.BootVolumeVpusPerGB
 
 	// Build instance options
 	instanceOptions := core.InstanceOptions{}
@@ -181,6 +204,49 @@ func (d *driverOCI) CreateInstance(ctx context.Context, publicKey string) (strin
 		InstanceOptions:    &instanceOptions,
 	}
 
+	if lo := d.cfg.LaunchOptions; lo != nil {
+		instanceDetails.LaunchOptions = &core.LaunchOptions{
+			Firmware:                        core.LaunchOptionsFirmwareEnum(lo.Firmware),
+			BootVolumeType:                  core.LaunchOptionsBootVolumeTypeEnum(lo.BootVolumeType),
+			RemoteDataVolumeType:            core.LaunchOptionsRemoteDataVolumeTypeEnum(lo.RemoteDataVolumeType),
+			NetworkType:                     core.LaunchOptionsNetworkTypeEnum(lo.NetworkType),
+			IsConsistentVolumeNamingEnabled: lo.IsConsistentVolumeNamingEnabled,
+			IsPvEncryptionInTransitEnabled:  lo.IsPvEncryptionInTransitEnabled,
+		}
+	}
+
+	if d.cfg.PlatformConfig != nil {
+		instanceDetails.PlatformConfig = launchInstancePlatformConfig(d.cfg.PlatformConfig)
+	}
+
+	if d.cfg.faultDomain != "" {
+		instanceDetails.FaultDomain = &d.cfg.faultDomain
+	}
+
+	if d.cfg.CapacityReservationID != "" {
+		instanceDetails.CapacityReservationId = &d.cfg.CapacityReservationID
+	}
+	if d.cfg.DedicatedVmHostID != "" {
+		instanceDetails.DedicatedVmHostId = &d.cfg.DedicatedVmHostID
+	}
+
+	if d.cfg.PreemptibleInstanceConfig != nil {
+		instanceDetails.PreemptibleInstanceConfig = &core.PreemptibleInstanceConfigDetails{
+			PreemptionAction: core.TerminatePreemptionAction{
+				PreserveBootVolume: &d.cfg.PreemptibleInstanceConfig.PreserveBootVolume,
+			},
+		}
+	}
+
+	instanceDetails.AgentConfig = launchInstanceAgentConfig(d.cfg)
+
+	if ac := d.cfg.AvailabilityConfig; ac != nil {
+		instanceDetails.AvailabilityConfig = &core.LaunchInstanceAvailabilityConfigDetails{
+			IsLiveMigrationPreferred: ac.IsLiveMigrationPreferred,
+			RecoveryAction:           core.LaunchInstanceAvailabilityConfigDetailsRecoveryActionEnum(ac.RecoveryAction),
+		}
+	}
+
 	if d.cfg.ShapeConfig.Ocpus != nil {
 		LaunchInstanceShapeConfigDetails := core.LaunchInstanceShapeConfigDetails{
 			Ocpus:       d.cfg.ShapeConfig.Ocpus,
@@ -194,11 +260,17 @@ func (d *driverOCI) CreateInstance(ctx context.Context, publicKey string) (strin
 		instanceDetails.ShapeConfig = &LaunchInstanceShapeConfigDetails
 	}
 
-	instance, err := d.computeClient.LaunchInstance(context.TODO(), core.LaunchInstanceRequest{
+	launchCtx, cancel := context.WithTimeout(ctx, d.cfg.InstanceLaunchTimeout)
+	defer cancel()
+	instance, err := d.computeClient.LaunchInstance(launchCtx, core.LaunchInstanceRequest{
 		LaunchInstanceDetails: instanceDetails,
 		RequestMetadata:       requestMetadata,
 	})
 
+	var e common.ServiceError
+	if errors.As(err, &e) && isOutOfCapacity(e) {
+		return "", fmt.Errorf("%w: %s", errOutOfCapacity, err)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -206,6 +278,179 @@ func (d *driverOCI) CreateInstance(ctx context.Context, publicKey string) (strin
 	return *instance.Id, nil
 }
 
+// isOutOfCapacity reports whether OCI rejected a launch for lack of capacity.
+func isOutOfCapacity(e common.ServiceError) bool {
+	return e.GetHTTPStatusCode() == http.StatusInternalServerError &&
+		strings.Contains(strings.ToLower(e.GetMessage()), "out of host capacity")
+}
+
+// launchInstanceAgentConfig returns the Oracle Cloud Agent configuration of
+// the instance, or nil to keep the OCI defaults.
+func launchInstanceAgentConfig(cfg *Config) *core.LaunchInstanceAgentConfigDetails {
+	var details *core.LaunchInstanceAgentConfigDetails
+	if ac := cfg.AgentConfig; ac != nil {
+		details = &core.LaunchInstanceAgentConfigDetails{
+			IsMonitoringDisabled:  ac.IsMonitoringDisabled,
+			IsManagementDisabled:  ac.IsManagementDisabled,
+			AreAllPluginsDisabled: ac.AreAllPluginsDisabled,
+		}
+		for _, plugin := range ac.PluginsConfig {
+			details.PluginsConfig = append(details.PluginsConfig, core.InstanceAgentPluginConfigDetails{
+				Name:         common.String(plugin.Name),
+				DesiredState: core.InstanceAgentPluginConfigDetailsDesiredStateEnum(plugin.DesiredState),
+			})
+		}
+	}
+
+	// Managed SSH sessions are served by the Bastion plugin and the
+	// run_command communicator by the Run Command plugin of the Oracle Cloud
+	// Agent running on the instance.
+	var required []string
+	if cfg.BastionID != "" && cfg.BastionSessionType == "MANAGED_SSH" {
+		required = append(required, "Bastion")
+	}
+	if cfg.Comm.Type == "run_command" {
+		required = append(required, "Compute Instance Run Command")
+	}
+	for _, name := range required {
+		if details == nil {
+			details = &core.LaunchInstanceAgentConfigDetails{}
+		}
+		if cfg.AgentConfig == nil || cfg.AgentConfig.pluginState(name) == "" {
+			details.PluginsConfig = append(details.PluginsConfig, core.InstanceAgentPluginConfigDetails{
+				Name:         common.String(name),
+				DesiredState: core.InstanceAgentPluginConfigDetailsDesiredStateEnabled,
+			})
+		}
+	}
+
+	return details
+}
+
+// launchInstancePlatformConfig returns the launch platform configuration
+// matching the configured platform type.
+func launchInstancePlatformConfig(pc *PlatformConfig) core.LaunchInstancePlatformConfig {
+	secureBoot := &pc.IsSecureBootEnabled
+	measuredBoot := &pc.IsMeasuredBootEnabled
+	tpm := &pc.IsTrustedPlatformModuleEnabled
+	memoryEncryption := &pc.IsMemoryEncryptionEnabled
+
+	switch pc.Type {
+	case "AMD_VM":
+		return core.AmdVmLaunchInstancePlatformConfig{IsSecureBootEnabled: secureBoot, IsMeasuredBootEnabled: measuredBoot, IsTrustedPlatformModuleEnabled: tpm, IsMemoryEncryptionEnabled: memoryEncryption}
+	case "INTEL_VM":
+		return core.IntelVmLaunchInstancePlatformConfig{IsSecureBootEnabled: secureBoot, IsMeasuredBootEnabled: measuredBoot, IsTrustedPlatformModuleEnabled: tpm, IsMemoryEncryptionEnabled: memoryEncryption}
+	case "AMD_MILAN_BM":
+		return core.AmdMilanBmLaunchInstancePlatformConfig{IsSecureBootEnabled: secureBoot, IsMeasuredBootEnabled: measuredBoot, IsTrustedPlatformModuleEnabled: tpm, IsMemoryEncryptionEnabled: memoryEncryption}
+	case "AMD_ROME_BM":
+		return core.AmdRomeBmLaunchInstancePlatformConfig{IsSecureBootEnabled: secureBoot, IsMeasuredBootEnabled: measuredBoot, IsTrustedPlatformModuleEnabled: tpm, IsMemoryEncryptionEnabled: memoryEncryption}
+	case "AMD_ROME_BM_GPU":
+		return core.AmdRomeBmGpuLaunchInstancePlatformConfig{IsSecureBootEnabled: secureBoot, IsMeasuredBootEnabled: measuredBoot, IsTrustedPlatformModuleEnabled: tpm, IsMemoryEncryptionEnabled: memoryEncryption}
+	case "INTEL_ICELAKE_BM":
+		return core.IntelIcelakeBmLaunchInstancePlatformConfig{IsSecureBootEnabled: secureBoot, IsMeasuredBootEnabled: measuredBoot, IsTrustedPlatformModuleEnabled: tpm, IsMemoryEncryptionEnabled: memoryEncryption}
+	default:
+		return core.IntelSkylakeBmLaunchInstancePlatformConfig{IsSecureBootEnabled: secureBoot, IsMeasuredBootEnabled: measuredBoot, IsTrustedPlatformModuleEnabled: tpm, IsMemoryEncryptionEnabled: memoryEncryption}
+	}
+}
+
+// GetInstanceSourceImage returns the image an instance was launched from.
+func (d *driverOCI) GetInstanceSourceImage(ctx context.Context, instanceID string) (core.Image, error) {
+	instance, err := d.computeClient.GetInstance(ctx, core.GetInstanceRequest{
+		InstanceId:      &instanceID,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return core.Image{}, err
+	}
+
+	source, ok := instance.SourceDetails.(core.InstanceSourceViaImageDetails)
+	if !ok || source.ImageId == nil {
+		return core.Image{}, fmt.Errorf("instance %s was not launched from an image", instanceID)
+	}
+
+	res, err := d.computeClient.GetImage(ctx, core.GetImageRequest{
+		ImageId:         source.ImageId,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return core.Image{}, err
+	}
+
+	return res.Image, nil
+}
+
+// GetSourceImage returns the base image of the instance, given by
+// base_image_ocid or the newest image matching base_image_filter.
+func (d *driverOCI) GetSourceImage(ctx context.Context) (core.Image, error) {
+	if d.cfg.BaseImageID != "" {
+		res, err := d.computeClient.GetImage(ctx, core.GetImageRequest{
+			ImageId:         &d.cfg.BaseImageID,
+			RequestMetadata: requestMetadata,
+		})
+		if err != nil {
+			return core.Image{}, err
+		}
+		return res.Image, nil
+	}
+
+	var image *core.Image
+	request := core.ListImagesRequest{
+		CompartmentId:          d.cfg.BaseImageFilter.CompartmentId,
+		DisplayName:            d.cfg.BaseImageFilter.DisplayName,
+		OperatingSystem:        d.cfg.BaseImageFilter.OperatingSystem,
+		OperatingSystemVersion: d.cfg.BaseImageFilter.OperatingSystemVersion,
+		Shape:                  d.cfg.BaseImageFilter.Shape,
+		LifecycleState:         "AVAILABLE",
+		SortBy:                 "TIMECREATED",
+		SortOrder:              "DESC",
+		RequestMetadata:        requestMetadata,
+		Page:                   common.String(""),
+	}
+
+	for request.Page != nil && image == nil {
+		// Pull images and determine which image ID to use, if BaseImageId not specified
+		response, err := d.computeClient.ListImages(ctx, request)
+		if err != nil {
+			return core.Image{}, err
+		}
+
+		if len(response.Items) == 0 && response.OpcNextPage == nil {
+			return core.Image{}, errors.New("base_image_filter returned no images")
+		}
+
+		if d.cfg.BaseImageFilter.DisplayNameSearch != nil {
+			// Return most recent image that matches regex
+			imageNameRegex, err := regexp.Compile(*d.cfg.BaseImageFilter.DisplayNameSearch)
+			if err != nil {
+				return core.Image{}, err
+			}
+			for _, item := range response.Items {
+				if imageNameRegex.MatchString(*item.DisplayName) {
+					image = &item
+					break
+				}
+			}
+
+			if image == nil && response.OpcNextPage == nil {
+				return core.Image{}, errors.New("no image matched display_name_search criteria")
+			}
+		} else {
+			// If no regex provided, simply return most recent image pulled
+			if len(response.Items) > 0 {
+				image = &response.Items[0]
+			}
+		}
+
+		request.Page = response.OpcNextPage
+	}
+
+	if image == nil {
+		return core.Image{}, errors.New("base_image_filter returned no images")
+	}
+
+	return *image, nil
+}
+
 // CreateImage creates a new custom image.
 func (d *driverOCI) CreateImage(ctx context.Context, id string) (core.Image, error) {
 	res, err := d.computeClient.CreateImage(ctx, core.CreateImageRequest{CreateImageDetails: core.CreateImageDetails{
@@ -228,9 +473,20 @@ func (d *driverOCI) CreateImage(ctx context.Context, id string) (core.Image, err
 
 // UpdateImageCapabilitySchema creates a new custom image.
 func (d *driverOCI) UpdateImageCapabilitySchema(ctx context.Context, imageId string) (core.UpdateComputeImageCapabilitySchemaResponse, error) {
+	return d.updateImageCapabilitySchema(ctx, d.computeClient, imageId)
+}
+
+// UpdateImageCapabilitySchemaInRegion applies the capability schema of the
+// image to a copy of it in the given region.
+func (d *driverOCI) UpdateImageCapabilitySchemaInRegion(ctx context.Context, region string, imageId string) error {
+	_, err := d.updateImageCapabilitySchema(ctx, d.computeClientForRegion(region), imageId)
+	return err
+}
+
+func (d *driverOCI) updateImageCapabilitySchema(ctx context.Context, computeClient core.ComputeClient, imageId string) (core.UpdateComputeImageCapabilitySchemaResponse, error) {
 
 	// get the schema associated with the newly created image
-	schema, err := d.computeClient.ListComputeImageCapabilitySchemas(context.Background(), core.ListComputeImageCapabilitySchemasRequest{
+	schema, err := computeClient.ListComputeImageCapabilitySchemas(ctx, core.ListComputeImageCapabilitySchemasRequest{
 		ImageId: &imageId,
 	})
 	if err != nil {
@@ -240,7 +496,7 @@ func (d *driverOCI) UpdateImageCapabilitySchema(ctx context.Context, imageId str
 	// and create the schema
 	if len(schema.Items) < 1 {
 		// get the global schema list
-		globalSchemaList, err := d.computeClient.ListComputeGlobalImageCapabilitySchemas(context.Background(), core.ListComputeGlobalImageCapabilitySchemasRequest{})
+		globalSchemaList, err := computeClient.ListComputeGlobalImageCapabilitySchemas(ctx, core.ListComputeGlobalImageCapabilitySchemasRequest{})
 		if err != nil {
 			return core.UpdateComputeImageCapabilitySchemaResponse{}, err
 		}
@@ -251,7 +507,7 @@ func (d *driverOCI) UpdateImageCapabilitySchema(ctx context.Context, imageId str
 		// get the global schema based on ocid and latest version guid
 		var globalSchemaId = globalSchemaList.Items[0].Id
 		var globalSchemaCurrentVersion = globalSchemaList.Items[0].CurrentVersionName
-		globalSchema, err := d.computeClient.GetComputeGlobalImageCapabilitySchemaVersion(context.Background(),
+		globalSchema, err := computeClient.GetComputeGlobalImageCapabilitySchemaVersion(ctx,
 			core.GetComputeGlobalImageCapabilitySchemaVersionRequest{ComputeGlobalImageCapabilitySchemaId: globalSchemaId,
 				ComputeGlobalImageCapabilitySchemaVersionName: globalSchemaCurrentVersion})
 		if err != nil {
@@ -289,13 +545,13 @@ func (d *driverOCI) UpdateImageCapabilitySchema(ctx context.Context, imageId str
 		},
 			OpcRetryToken: common.String(uuid.TimeOrderedUUID()),
 		}
-		_, err = d.computeClient.CreateComputeImageCapabilitySchema(context.Background(), req)
+		_, err = computeClient.CreateComputeImageCapabilitySchema(ctx, req)
 		if err != nil {
 			return core.UpdateComputeImageCapabilitySchemaResponse{}, err
 		}
 
 		// try to get the schema again, now it should be good
-		schema, err = d.computeClient.ListComputeImageCapabilitySchemas(context.Background(),
+		schema, err = computeClient.ListComputeImageCapabilitySchemas(ctx,
 			core.ListComputeImageCapabilitySchemasRequest{
 				ImageId: &imageId,
 			})
@@ -312,8 +568,45 @@ func (d *driverOCI) UpdateImageCapabilitySchema(ctx context.Context, imageId str
 		schema.Items[0].SchemaData["Network.AttachmentType"] = core.EnumStringImageCapabilitySchemaDescriptor{Values: []string{"E1000", "VFIO", "PARAVIRTUALIZED"}, DefaultValue: &d.cfg.NicAttachmentType, Source: "IMAGE"}
 	}
 
+	// instances launched from the image default to the launch options of
+	// the build instance
+	if lo := d.cfg.LaunchOptions; lo != nil {
+		if lo.Firmware != "" {
+			schema.Items[0].SchemaData["Compute.Firmware"] = core.EnumStringImageCapabilitySchemaDescriptor{Values: []string{"BIOS", "UEFI_64"}, DefaultValue: &lo.Firmware, Source: "IMAGE"}
+		}
+		if lo.BootVolumeType != "" {
+			schema.Items[0].SchemaData["Storage.BootVolumeType"] = core.EnumStringImageCapabilitySchemaDescriptor{Values: volumeTypes, DefaultValue: &lo.BootVolumeType, Source: "IMAGE"}
+		}
+		if lo.RemoteDataVolumeType != "" {
+			schema.Items[0].SchemaData["Storage.RemoteDataVolumeType"] = core.EnumStringImageCapabilitySchemaDescriptor{Values: volumeTypes, DefaultValue: &lo.RemoteDataVolumeType, Source: "IMAGE"}
+		}
+		if lo.NetworkType != "" {
+			schema.Items[0].SchemaData["Network.AttachmentType"] = core.EnumStringImageCapabilitySchemaDescriptor{Values: []string{"E1000", "VFIO", "PARAVIRTUALIZED"}, DefaultValue: &lo.NetworkType, Source: "IMAGE"}
+		}
+		if lo.IsConsistentVolumeNamingEnabled != nil {
+			schema.Items[0].SchemaData["Storage.ConsistentVolumeNaming"] = core.BooleanImageCapabilitySchemaDescriptor{DefaultValue: lo.IsConsistentVolumeNamingEnabled, Source: "IMAGE"}
+		}
+		if lo.IsPvEncryptionInTransitEnabled != nil {
+			schema.Items[0].SchemaData["Storage.ParaVirtualization.EncryptionInTransit"] = core.BooleanImageCapabilitySchemaDescriptor{DefaultValue: lo.IsPvEncryptionInTransitEnabled, Source: "IMAGE"}
+		}
+	}
+
+	// instances launched from the image keep the platform features of the
+	// build instance
+	if pc := d.cfg.PlatformConfig; pc != nil {
+		if pc.IsSecureBootEnabled || pc.IsMeasuredBootEnabled || pc.IsTrustedPlatformModuleEnabled {
+			schema.Items[0].SchemaData["Compute.Firmware"] = core.EnumStringImageCapabilitySchemaDescriptor{Values: []string{"BIOS", "UEFI_64"}, DefaultValue: common.String("UEFI_64"), Source: "IMAGE"}
+		}
+		if pc.IsSecureBootEnabled {
+			schema.Items[0].SchemaData["Compute.SecureBoot"] = core.BooleanImageCapabilitySchemaDescriptor{DefaultValue: common.Bool(true), Source: "IMAGE"}
+		}
+		if pc.IsMemoryEncryptionEnabled {
+			schema.Items[0].SchemaData["Compute.AMD_SecureEncryptedVirtualization"] = core.BooleanImageCapabilitySchemaDescriptor{DefaultValue: common.Bool(true), Source: "IMAGE"}
+		}
+	}
+
 	// update the new fields to the schema definition
-	resp, err := d.computeClient.UpdateComputeImageCapabilitySchema(ctx,
+	resp, err := computeClient.UpdateComputeImageCapabilitySchema(ctx,
 		core.UpdateComputeImageCapabilitySchemaRequest{ComputeImageCapabilitySchemaId: schema.Items[0].Id,
 			UpdateComputeImageCapabilitySchemaDetails: core.UpdateComputeImageCapabilitySchemaDetails{SchemaData: schema.Items[0].SchemaData,
 				FreeformTags: d.cfg.Tags,
@@ -336,124 +629,1404 @@ func (d *driverOCI) DeleteImage(ctx context.Context, id string) error {
 	return err
 }
 
-// GetInstanceIP returns the public or private IP corresponding to the given instance id.
-func (d *driverOCI) GetInstanceIP(ctx context.Context, id string) (string, error) {
-	vnics, err := d.computeClient.ListVnicAttachments(ctx, core.ListVnicAttachmentsRequest{
-		InstanceId:      &id,
-		CompartmentId:   &d.cfg.CompartmentID,
-		RequestMetadata: requestMetadata,
+// ListImages returns the images matching the request, going through every
+// page of results.
+func (d *driverOCI) ListImages(ctx context.Context, request core.ListImagesRequest) ([]core.Image, error) {
+	return listImages(ctx, d.computeClient, request)
+}
+
+func listImages(ctx context.Context, computeClient core.ComputeClient, request core.ListImagesRequest) ([]core.Image, error) {
+	request.RequestMetadata = requestMetadata
+
+	var images []core.Image
+	for {
+		response, err := computeClient.ListImages(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, response.Items...)
+
+		if response.OpcNextPage == nil {
+			return images, nil
+		}
+		request.Page = response.OpcNextPage
+	}
+}
+
+// ListCustomImages returns the available custom images of a compartment,
+// leaving out the platform images listed along with them.
+func (d *driverOCI) ListCustomImages(ctx context.Context, compartmentID string) ([]core.Image, error) {
+	return listCustomImages(ctx, d.computeClient, compartmentID)
+}
+
+// ListCustomImagesInRegion returns the available custom images of a
+// compartment in the given region.
+func (d *driverOCI) ListCustomImagesInRegion(ctx context.Context, region string, compartmentID string) ([]core.Image, error) {
+	return listCustomImages(ctx, d.computeClientForRegion(region), compartmentID)
+}
+
+func listCustomImages(ctx context.Context, computeClient core.ComputeClient, compartmentID string) ([]core.Image, error) {
+	images, err := listImages(ctx, computeClient, core.ListImagesRequest{
+		CompartmentId:  &compartmentID,
+		LifecycleState: core.ImageLifecycleStateAvailable,
 	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if len(vnics.Items) == 0 {
-		return "", errors.New("instance has zero VNICs")
+	var custom []core.Image
+	for _, image := range images {
+		if image.CompartmentId != nil && *image.CompartmentId == compartmentID {
+			custom = append(custom, image)
+		}
 	}
 
-	vnic, err := d.vcnClient.GetVnic(ctx, core.GetVnicRequest{
-		VnicId:          vnics.Items[0].VnicId,
+	return custom, nil
+}
+
+// ListAvailabilityDomains returns the availability domains of the region.
+func (d *driverOCI) ListAvailabilityDomains(ctx context.Context, compartmentID string) ([]identity.AvailabilityDomain, error) {
+	res, err := d.identityClient.ListAvailabilityDomains(ctx, identity.ListAvailabilityDomainsRequest{
+		CompartmentId:   &compartmentID,
 		RequestMetadata: requestMetadata,
 	})
 	if err != nil {
-		return "", fmt.Errorf("error getting VNIC details: %s", err)
+		return nil, err
 	}
 
-	if d.cfg.UsePrivateIP {
-		return *vnic.PrivateIp, nil
-	}
+	return res.Items, nil
+}
 
-	if vnic.PublicIp == nil {
-		return "", fmt.Errorf("error getting VNIC Public Ip for: %s", id)
+// GetCompartment returns the compartment with the given OCID.
+func (d *driverOCI) GetCompartment(ctx context.Context, id string) (identity.Compartment, error) {
+	res, err := d.identityClient.GetCompartment(ctx, identity.GetCompartmentRequest{
+		CompartmentId:   &id,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return identity.Compartment{}, err
 	}
 
-	return *vnic.PublicIp, nil
+	return res.Compartment, nil
 }
 
-func (d *driverOCI) GetInstanceInitialCredentials(ctx context.Context, id string) (string, string, error) {
-	credentials, err := d.computeClient.GetWindowsInstanceInitialCredentials(ctx, core.GetWindowsInstanceInitialCredentialsRequest{
-		InstanceId:      &id,
+// GetSubnet returns the subnet with the given OCID.
+func (d *driverOCI) GetSubnet(ctx context.Context, id string) (core.Subnet, error) {
+	res, err := d.vcnClient.GetSubnet(ctx, core.GetSubnetRequest{
+		SubnetId:        &id,
 		RequestMetadata: requestMetadata,
 	})
 	if err != nil {
-		return "", "", err
+		return core.Subnet{}, err
 	}
 
-	return *credentials.InstanceCredentials.Username, *credentials.InstanceCredentials.Password, err
+	return res.Subnet, nil
 }
 
-// TerminateInstance terminates a compute instance.
-func (d *driverOCI) TerminateInstance(ctx context.Context, id string) error {
-	_, err := d.computeClient.TerminateInstance(ctx, core.TerminateInstanceRequest{
-		InstanceId:      &id,
-		RequestMetadata: requestMetadata,
+// GetNetworkSecurityGroup returns the network security group with the given
+// OCID.
+func (d *driverOCI) GetNetworkSecurityGroup(ctx context.Context, id string) (core.NetworkSecurityGroup, error) {
+	res, err := d.vcnClient.GetNetworkSecurityGroup(ctx, core.GetNetworkSecurityGroupRequest{
+		NetworkSecurityGroupId: &id,
+		RequestMetadata:        requestMetadata,
 	})
-	return err
+	if err != nil {
+		return core.NetworkSecurityGroup{}, err
+	}
+
+	return res.NetworkSecurityGroup, nil
 }
 
-// WaitForImageCreation waits for a provisioning custom image to reach the
-// "AVAILABLE" state.
-func (d *driverOCI) WaitForImageCreation(ctx context.Context, id string) error {
-	return waitForResourceToReachState(
-		func(string) (string, error) {
-			image, err := d.computeClient.GetImage(ctx, core.GetImageRequest{
-				ImageId:         &id,
-				RequestMetadata: requestMetadata,
-			})
-			if err != nil {
-				return "", err
-			}
-			return string(image.LifecycleState), nil
-		},
-		id,
-		[]string{"PROVISIONING"},
-		"AVAILABLE",
-		0,             //Unlimited Retries
-		5*time.Second, //5 second wait between retries
-	)
+// ListShapes returns the shapes offered in an availability domain to the
+// compartment of the build, only those compatible with the given image when
+// imageID is set.
+func (d *driverOCI) ListShapes(ctx context.Context, availabilityDomain string, imageID string) ([]core.Shape, error) {
+	request := core.ListShapesRequest{
+		CompartmentId:      &d.cfg.CompartmentID,
+		AvailabilityDomain: &availabilityDomain,
+		RequestMetadata:    requestMetadata,
+	}
+	if imageID != "" {
+		request.ImageId = &imageID
+	}
+
+	var shapes []core.Shape
+	for {
+		response, err := d.computeClient.ListShapes(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		shapes = append(shapes, response.Items...)
+
+		if response.OpcNextPage == nil {
+			return shapes, nil
+		}
+		request.Page = response.OpcNextPage
+	}
 }
 
-// WaitForInstanceState waits for an instance to reach the a given terminal
-// state.
-func (d *driverOCI) WaitForInstanceState(ctx context.Context, id string, waitStates []string, terminalState string) error {
-	return waitForResourceToReachState(
-		func(string) (string, error) {
-			instance, err := d.computeClient.GetInstance(ctx, core.GetInstanceRequest{
-				InstanceId:      &id,
-				RequestMetadata: requestMetadata,
-			})
-			if err != nil {
-				return "", err
-			}
-			return string(instance.LifecycleState), nil
-		},
-		id,
-		waitStates,
-		terminalState,
-		0,             //Unlimited Retries
-		5*time.Second, //5 second wait between retries
-	)
+// ListSubnets returns the available subnets of a compartment, optionally
+// only those of the given VCN.
+func (d *driverOCI) ListSubnets(ctx context.Context, compartmentID string, vcnID string) ([]core.Subnet, error) {
+	request := core.ListSubnetsRequest{
+		CompartmentId:   &compartmentID,
+		LifecycleState:  core.SubnetLifecycleStateAvailable,
+		RequestMetadata: requestMetadata,
+	}
+	if vcnID != "" {
+		request.VcnId = &vcnID
+	}
+
+	var subnets []core.Subnet
+	for {
+		response, err := d.vcnClient.ListSubnets(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		subnets = append(subnets, response.Items...)
+
+		if response.OpcNextPage == nil {
+			return subnets, nil
+		}
+		request.Page = response.OpcNextPage
+	}
 }
 
-// WaitForResourceToReachState checks the response of a request through a
-// polled get and waits until the desired state or until the max retried has
-// been reached.
-func waitForResourceToReachState(getResourceState func(string) (string, error), id string, waitStates []string, terminalState string, maxRetries int, waitDuration time.Duration) error {
-	for i := 0; maxRetries == 0 || i < maxRetries; i++ {
-		state, err := getResourceState(id)
+// ListNetworkSecurityGroups returns the available network security groups of
+// a VCN.
+func (d *driverOCI) ListNetworkSecurityGroups(ctx context.Context, vcnID string) ([]core.NetworkSecurityGroup, error) {
+	request := core.ListNetworkSecurityGroupsRequest{
+		VcnId:           &vcnID,
+		LifecycleState:  core.NetworkSecurityGroupLifecycleStateAvailable,
+		RequestMetadata: requestMetadata,
+	}
+
+	var groups []core.NetworkSecurityGroup
+	for {
+		response, err := d.vcnClient.ListNetworkSecurityGroups(ctx, request)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		groups = append(groups, response.Items...)
 
-		if stringSliceContains(waitStates, state) {
-			time.Sleep(waitDuration)
-			continue
-		} else if state == terminalState {
-			return nil
+		if response.OpcNextPage == nil {
+			return groups, nil
 		}
-		return fmt.Errorf("unexpected resource state %q, expecting a waiting state %s or terminal state  %q ", state, waitStates, terminalState)
+		request.Page = response.OpcNextPage
+	}
+}
+
+// GetSecretBundle returns the version of a Vault secret in the given stage,
+// or the current version when stage is empty.
+func (d *driverOCI) GetSecretBundle(ctx context.Context, secretID string, stage string) (secrets.SecretBundle, error) {
+	res, err := d.secretsClient.GetSecretBundle(ctx, secrets.GetSecretBundleRequest{
+		SecretId:        &secretID,
+		Stage:           secrets.GetSecretBundleStageEnum(stage),
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return secrets.SecretBundle{}, err
+	}
+
+	return res.SecretBundle, nil
+}
+
+// GetSecretBundleByName is like GetSecretBundle for the secret with the given
+// name in a vault.
+func (d *driverOCI) GetSecretBundleByName(ctx context.Context, vaultID string, name string, stage string) (secrets.SecretBundle, error) {
+	res, err := d.secretsClient.GetSecretBundleByName(ctx, secrets.GetSecretBundleByNameRequest{
+		VaultId:         &vaultID,
+		SecretName:      &name,
+		Stage:           secrets.GetSecretBundleByNameStageEnum(stage),
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return secrets.SecretBundle{}, err
+	}
+
+	return res.SecretBundle, nil
+}
+
+// UpdateImageFreeformTags replaces the freeform tags of a custom image.
+func (d *driverOCI) UpdateImageFreeformTags(ctx context.Context, id string, tags map[string]string) error {
+	return updateImageFreeformTags(ctx, d.computeClient, id, tags)
+}
+
+// UpdateImageFreeformTagsInRegion replaces the freeform tags of a custom
+// image living in the given region.
+func (d *driverOCI) UpdateImageFreeformTagsInRegion(ctx context.Context, region string, id string, tags map[string]string) error {
+	return updateImageFreeformTags(ctx, d.computeClientForRegion(region), id, tags)
+}
+
+func updateImageFreeformTags(ctx context.Context, computeClient core.ComputeClient, id string, tags map[string]string) error {
+	_, err := computeClient.UpdateImage(ctx, core.UpdateImageRequest{
+		ImageId: &id,
+		UpdateImageDetails: core.UpdateImageDetails{
+			FreeformTags: tags,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	return err
+}
+
+// GetNamespace returns the Object Storage namespace of the tenancy.
+func (d *driverOCI) GetNamespace(ctx context.Context) (string, error) {
+	namespace, err := d.objectStorageClient.GetNamespace(ctx, objectstorage.GetNamespaceRequest{
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error getting Object Storage namespace: %s", err)
+	}
+
+	return *namespace.Value, nil
+}
+
+// ExportImage exports a custom image to an Object Storage object, waits for
+// the export to finish and returns the URI of the object.
+func (d *driverOCI) ExportImage(ctx context.Context, id string, namespace string, bucket string, objectName string, format string) (string, error) {
+	res, err := d.computeClient.ExportImage(ctx, core.ExportImageRequest{
+		ImageId: &id,
+		ExportImageDetails: core.ExportImageViaObjectStorageTupleDetails{
+			BucketName:    &bucket,
+			NamespaceName: &namespace,
+			ObjectName:    &objectName,
+			ExportFormat:  core.ExportImageDetailsExportFormatEnum(format),
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := d.waitForWorkRequest(ctx, *res.OpcWorkRequestId); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/n/%s/b/%s/o/%s", d.objectStorageClient.Endpoint(), namespace, bucket, url.PathEscape(objectName)), nil
+}
+
+// CreateObjectReadURI creates a pre-authenticated request granting read
+// access to an Object Storage object until expires and returns its URI.
+func (d *driverOCI) CreateObjectReadURI(ctx context.Context, namespace string, bucket string, objectName string, expires time.Time) (string, error) {
+	par, err := d.objectStorageClient.CreatePreauthenticatedRequest(ctx, objectstorage.CreatePreauthenticatedRequestRequest{
+		NamespaceName: &namespace,
+		BucketName:    &bucket,
+		CreatePreauthenticatedRequestDetails: objectstorage.CreatePreauthenticatedRequestDetails{
+			Name:        common.String(fmt.Sprintf("packer-%s", objectName)),
+			ObjectName:  &objectName,
+			AccessType:  objectstorage.CreatePreauthenticatedRequestDetailsAccessTypeObjectread,
+			TimeExpires: &common.SDKTime{Time: expires},
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error creating pre-authenticated request for %s: %s", objectName, err)
+	}
+
+	return d.objectStorageClient.Endpoint() + *par.AccessUri, nil
+}
+
+// CreateObjectWriteURI creates a pre-authenticated request granting write
+// access to an Object Storage object until expires and returns its URI.
+func (d *driverOCI) CreateObjectWriteURI(ctx context.Context, namespace string, bucket string, objectName string, expires time.Time) (string, error) {
+	par, err := d.objectStorageClient.CreatePreauthenticatedRequest(ctx, objectstorage.CreatePreauthenticatedRequestRequest{
+		NamespaceName: &namespace,
+		BucketName:    &bucket,
+		CreatePreauthenticatedRequestDetails: objectstorage.CreatePreauthenticatedRequestDetails{
+			Name:        common.String(fmt.Sprintf("packer-%s", objectName)),
+			ObjectName:  &objectName,
+			AccessType:  objectstorage.CreatePreauthenticatedRequestDetailsAccessTypeObjectwrite,
+			TimeExpires: &common.SDKTime{Time: expires},
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error creating pre-authenticated request for %s: %s", objectName, err)
+	}
+
+	return d.objectStorageClient.Endpoint() + *par.AccessUri, nil
+}
+
+// DownloadObject writes the content of an Object Storage object to w.
+func (d *driverOCI) DownloadObject(ctx context.Context, namespace string, bucket string, objectName string, w io.Writer) error {
+	res, err := d.objectStorageClient.GetObject(ctx, objectstorage.GetObjectRequest{
+		NamespaceName:   &namespace,
+		BucketName:      &bucket,
+		ObjectName:      &objectName,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return err
+	}
+	defer res.Content.Close()
+
+	_, err = io.Copy(w, res.Content)
+	return err
+}
+
+// DeleteObject deletes an Object Storage object.
+func (d *driverOCI) DeleteObject(ctx context.Context, namespace string, bucket string, objectName string) error {
+	_, err := d.objectStorageClient.DeleteObject(ctx, objectstorage.DeleteObjectRequest{
+		NamespaceName:   &namespace,
+		BucketName:      &bucket,
+		ObjectName:      &objectName,
+		RequestMetadata: requestMetadata,
+	})
+	return err
+}
+
+// DeleteObjectURIs deletes the pre-authenticated requests granting access to
+// an Object Storage object.
+func (d *driverOCI) DeleteObjectURIs(ctx context.Context, namespace string, bucket string, objectName string) error {
+	request := objectstorage.ListPreauthenticatedRequestsRequest{
+		NamespaceName:    &namespace,
+		BucketName:       &bucket,
+		ObjectNamePrefix: &objectName,
+		RequestMetadata:  requestMetadata,
+	}
+	for {
+		res, err := d.objectStorageClient.ListPreauthenticatedRequests(ctx, request)
+		if err != nil {
+			return err
+		}
+
+		for _, par := range res.Items {
+			if par.ObjectName == nil || *par.ObjectName != objectName {
+				continue
+			}
+			_, err := d.objectStorageClient.DeletePreauthenticatedRequest(ctx, objectstorage.DeletePreauthenticatedRequestRequest{
+				NamespaceName:   &namespace,
+				BucketName:      &bucket,
+				ParId:           par.Id,
+				RequestMetadata: requestMetadata,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if res.OpcNextPage == nil {
+			return nil
+		}
+		request.Page = res.OpcNextPage
+	}
+}
+
+// UploadObject uploads a local file to an Object Storage object, using a
+// multipart upload for large files.
+func (d *driverOCI) UploadObject(ctx context.Context, namespace string, bucket string, objectName string, path string) error {
+	// Disk images are large and a single part can take longer to upload than
+	// the default client timeout allows.
+	objectStorageClient := d.objectStorageClient
+	if httpClient, ok := objectStorageClient.HTTPClient.(*http.Client); ok {
+		uploadClient := *httpClient
+		uploadClient.Timeout = 0
+		objectStorageClient.HTTPClient = &uploadClient
+	}
+
+	_, err := transfer.NewUploadManager().UploadFile(ctx, transfer.UploadFileRequest{
+		UploadRequest: transfer.UploadRequest{
+			NamespaceName:       &namespace,
+			BucketName:          &bucket,
+			ObjectName:          &objectName,
+			ObjectStorageClient: &objectStorageClient,
+			RequestMetadata:     requestMetadata,
+		},
+		FilePath: path,
+	})
+	return err
+}
+
+// ImportImage creates a custom image in the given region from an image file
+// stored in Object Storage.
+func (d *driverOCI) ImportImage(ctx context.Context, region string, source core.ImageSourceDetails) (core.Image, error) {
+	computeClient := d.computeClientForRegion(region)
+	res, err := computeClient.CreateImage(ctx, core.CreateImageRequest{CreateImageDetails: core.CreateImageDetails{
+		CompartmentId:      &d.cfg.ImageCompartmentID,
+		DisplayName:        &d.cfg.ImageName,
+		FreeformTags:       d.cfg.Tags,
+		DefinedTags:        d.cfg.DefinedTags,
+		LaunchMode:         core.CreateImageDetailsLaunchModeEnum(d.cfg.LaunchMode),
+		ImageSourceDetails: source,
+	},
+		RequestMetadata: requestMetadata,
+	})
+
+	if err != nil {
+		return core.Image{}, err
+	}
+
+	return res.Image, nil
+}
+
+// WaitForImageImport waits for an image importing into the given region to
+// reach the "AVAILABLE" state.
+func (d *driverOCI) WaitForImageImport(ctx context.Context, region string, id string) error {
+	computeClient := d.computeClientForRegion(region)
+	return waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			image, err := computeClient.GetImage(ctx, core.GetImageRequest{
+				ImageId:         &id,
+				RequestMetadata: requestMetadata,
+			})
+			if err != nil {
+				return "", err
+			}
+			return string(image.LifecycleState), nil
+		},
+		id,
+		[]string{"PROVISIONING", "IMPORTING"},
+		"AVAILABLE",
+		d.cfg.ImageCreateTimeout,
+		5*time.Second,
+	)
+}
+
+// DeleteImageInRegion deletes a custom image living in the given region.
+func (d *driverOCI) DeleteImageInRegion(ctx context.Context, region string, id string) error {
+	computeClient := d.computeClientForRegion(region)
+	_, err := computeClient.DeleteImage(ctx, core.DeleteImageRequest{
+		ImageId:         &id,
+		RequestMetadata: requestMetadata,
+	})
+	return err
+}
+
+// computeClientForRegion returns a copy of the compute client that sends its
+// requests to the given region.
+func (d *driverOCI) computeClientForRegion(region string) core.ComputeClient {
+	computeClient := d.computeClient
+	computeClient.SetRegion(region)
+	return computeClient
+}
+
+// CreateTemporaryNetwork creates a VCN with an internet gateway, route table,
+// security list and public subnet in the build compartment. Ingress is only
+// allowed to the communicator port. If creation fails part way, the
+// resources created so far are returned along with the error.
+func (d *driverOCI) CreateTemporaryNetwork(ctx context.Context, port int) (TemporaryNetwork, error) {
+	var network TemporaryNetwork
+	displayName := fmt.Sprintf("packer-%s", uuid.TimeOrderedUUID())
+	cidrBlock := d.cfg.TemporaryVcnCidrBlock
+
+	vcn, err := d.vcnClient.CreateVcn(ctx, core.CreateVcnRequest{
+		CreateVcnDetails: core.CreateVcnDetails{
+			CompartmentId: &d.cfg.CompartmentID,
+			CidrBlocks:    []string{cidrBlock},
+			DisplayName:   &displayName,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return network, fmt.Errorf("error creating VCN: %s", err)
+	}
+	network.VcnID = *vcn.Id
+
+	err = waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			vcn, err := d.vcnClient.GetVcn(ctx, core.GetVcnRequest{VcnId: &network.VcnID, RequestMetadata: requestMetadata})
+			if err != nil {
+				return "", err
+			}
+			return string(vcn.LifecycleState), nil
+		},
+		network.VcnID, []string{"PROVISIONING"}, "AVAILABLE", d.cfg.ResourceTimeout, 5*time.Second,
+	)
+	if err != nil {
+		return network, fmt.Errorf("error waiting for VCN: %s", err)
+	}
+
+	gateway, err := d.vcnClient.CreateInternetGateway(ctx, core.CreateInternetGatewayRequest{
+		CreateInternetGatewayDetails: core.CreateInternetGatewayDetails{
+			CompartmentId: &d.cfg.CompartmentID,
+			VcnId:         &network.VcnID,
+			IsEnabled:     common.Bool(true),
+			DisplayName:   &displayName,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return network, fmt.Errorf("error creating internet gateway: %s", err)
+	}
+	network.InternetGatewayID = *gateway.Id
+
+	routeTable, err := d.vcnClient.CreateRouteTable(ctx, core.CreateRouteTableRequest{
+		CreateRouteTableDetails: core.CreateRouteTableDetails{
+			CompartmentId: &d.cfg.CompartmentID,
+			VcnId:         &network.VcnID,
+			DisplayName:   &displayName,
+			RouteRules: []core.RouteRule{{
+				NetworkEntityId: &network.InternetGatewayID,
+				Destination:     common.String("0.0.0.0/0"),
+				DestinationType: core.RouteRuleDestinationTypeCidrBlock,
+			}},
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return network, fmt.Errorf("error creating route table: %s", err)
+	}
+	network.RouteTableID = *routeTable.Id
+
+	ingressRules := make([]core.IngressSecurityRule, 0, len(d.cfg.TemporaryNetworkSourceCidrs))
+	// Communicators without a port, like run_command, need no inbound access.
+	if port != 0 {
+		for _, source := range d.cfg.TemporaryNetworkSourceCidrs {
+			ingressRules = append(ingressRules, core.IngressSecurityRule{
+				Protocol: common.String("6"), // TCP
+				Source:   common.String(source),
+				TcpOptions: &core.TcpOptions{
+					DestinationPortRange: &core.PortRange{Min: common.Int(port), Max: common.Int(port)},
+				},
+			})
+		}
+	}
+
+	securityList, err := d.vcnClient.CreateSecurityList(ctx, core.CreateSecurityListRequest{
+		CreateSecurityListDetails: core.CreateSecurityListDetails{
+			CompartmentId:        &d.cfg.CompartmentID,
+			VcnId:                &network.VcnID,
+			DisplayName:          &displayName,
+			IngressSecurityRules: ingressRules,
+			EgressSecurityRules: []core.EgressSecurityRule{{
+				Protocol:    common.String("all"),
+				Destination: common.String("0.0.0.0/0"),
+			}},
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return network, fmt.Errorf("error creating security list: %s", err)
+	}
+	network.SecurityListID = *securityList.Id
+
+	subnet, err := d.vcnClient.CreateSubnet(ctx, core.CreateSubnetRequest{
+		CreateSubnetDetails: core.CreateSubnetDetails{
+			CompartmentId:   &d.cfg.CompartmentID,
+			VcnId:           &network.VcnID,
+			CidrBlock:       &cidrBlock,
+			DisplayName:     &displayName,
+			RouteTableId:    &network.RouteTableID,
+			SecurityListIds: []string{network.SecurityListID},
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return network, fmt.Errorf("error creating subnet: %s", err)
+	}
+	network.SubnetID = *subnet.Id
+
+	err = waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			subnet, err := d.vcnClient.GetSubnet(ctx, core.GetSubnetRequest{SubnetId: &network.SubnetID, RequestMetadata: requestMetadata})
+			if err != nil {
+				return "", err
+			}
+			return string(subnet.LifecycleState), nil
+		},
+		network.SubnetID, []string{"PROVISIONING"}, "AVAILABLE", d.cfg.ResourceTimeout, 5*time.Second,
+	)
+	if err != nil {
+		return network, fmt.Errorf("error waiting for subnet: %s", err)
+	}
+
+	return network, nil
+}
+
+// DeleteTemporaryNetwork deletes the networking resources created by
+// CreateTemporaryNetwork in reverse order of creation.
+func (d *driverOCI) DeleteTemporaryNetwork(ctx context.Context, network TemporaryNetwork) error {
+	if network.SubnetID != "" {
+		// The VNIC of a just terminated instance can keep the subnet busy
+		// for a little while.
+		if err := retryWhileInUse(ctx, network.SubnetID, d.cfg.ResourceTimeout, func() error {
+			_, err := d.vcnClient.DeleteSubnet(ctx, core.DeleteSubnetRequest{
+				SubnetId:        &network.SubnetID,
+				RequestMetadata: requestMetadata,
+			})
+			return err
+		}); err != nil {
+			return fmt.Errorf("error deleting subnet %s: %s", network.SubnetID, err)
+		}
+
+		// The route table and security list can't be deleted while the
+		// subnet still uses them.
+		err := waitForResourceToReachState(
+			ctx,
+			func(string) (string, error) {
+				subnet, err := d.vcnClient.GetSubnet(ctx, core.GetSubnetRequest{SubnetId: &network.SubnetID, RequestMetadata: requestMetadata})
+				return terminatedIfNotFound(string(subnet.LifecycleState), err)
+			},
+			network.SubnetID, []string{"AVAILABLE", "TERMINATING"}, "TERMINATED", d.cfg.ResourceTimeout, 5*time.Second,
+		)
+		if err != nil {
+			return fmt.Errorf("error waiting for subnet %s to be deleted: %s", network.SubnetID, err)
+		}
+	}
+
+	if network.SecurityListID != "" {
+		if _, err := d.vcnClient.DeleteSecurityList(ctx, core.DeleteSecurityListRequest{
+			SecurityListId:  &network.SecurityListID,
+			RequestMetadata: requestMetadata,
+		}); err != nil {
+			return fmt.Errorf("error deleting security list %s: %s", network.SecurityListID, err)
+		}
+	}
+
+	if network.RouteTableID != "" {
+		if _, err := d.vcnClient.DeleteRouteTable(ctx, core.DeleteRouteTableRequest{
+			RtId:            &network.RouteTableID,
+			RequestMetadata: requestMetadata,
+		}); err != nil {
+			return fmt.Errorf("error deleting route table %s: %s", network.RouteTableID, err)
+		}
+	}
+
+	if network.InternetGatewayID != "" {
+		if _, err := d.vcnClient.DeleteInternetGateway(ctx, core.DeleteInternetGatewayRequest{
+			IgId:            &network.InternetGatewayID,
+			RequestMetadata: requestMetadata,
+		}); err != nil {
+			return fmt.Errorf("error deleting internet gateway %s: %s", network.InternetGatewayID, err)
+		}
+	}
+
+	if network.VcnID != "" {
+		// The VCN can only be deleted once everything in it is gone.
+		if err := retryWhileInUse(ctx, network.VcnID, d.cfg.ResourceTimeout, func() error {
+			_, err := d.vcnClient.DeleteVcn(ctx, core.DeleteVcnRequest{
+				VcnId:           &network.VcnID,
+				RequestMetadata: requestMetadata,
+			})
+			return err
+		}); err != nil {
+			return fmt.Errorf("error deleting VCN %s: %s", network.VcnID, err)
+		}
+	}
+
+	return nil
+}
+
+// retryWhileInUse retries a delete request for up to timeout for as long as
+// OCI reports a conflict because the resource is still in use.
+func retryWhileInUse(ctx context.Context, id string, timeout time.Duration, deleteResource func() error) error {
+	return waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			err := deleteResource()
+			var e common.ServiceError
+			if errors.As(err, &e) && e.GetHTTPStatusCode() == http.StatusConflict {
+				return "IN_USE", nil
+			}
+			if err != nil {
+				return "", err
+			}
+			return "DELETED", nil
+		},
+		id,
+		[]string{"IN_USE"},
+		"DELETED",
+		timeout,
+		5*time.Second,
+	)
+}
+
+// terminatedIfNotFound maps a 404 returned while polling a deleted resource
+// to the "TERMINATED" state.
+func terminatedIfNotFound(state string, err error) (string, error) {
+	var e common.ServiceError
+	if errors.As(err, &e) && e.GetHTTPStatusCode() == http.StatusNotFound {
+		return "TERMINATED", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return state, nil
+}
+
+// GetInstanceBootVolumeID returns the OCID of the boot volume of the given
+// instance.
+func (d *driverOCI) GetInstanceBootVolumeID(ctx context.Context, instanceID string) (string, error) {
+	instance, err := d.computeClient.GetInstance(ctx, core.GetInstanceRequest{
+		InstanceId:      &instanceID,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	attachments, err := d.computeClient.ListBootVolumeAttachments(ctx, core.ListBootVolumeAttachmentsRequest{
+		AvailabilityDomain: instance.AvailabilityDomain,
+		CompartmentId:      instance.CompartmentId,
+		InstanceId:         &instanceID,
+		RequestMetadata:    requestMetadata,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(attachments.Items) == 0 || attachments.Items[0].BootVolumeId == nil {
+		return "", fmt.Errorf("no boot volume attached to instance %s", instanceID)
+	}
+
+	return *attachments.Items[0].BootVolumeId, nil
+}
+
+// CreateBootVolumeBackup backs up the boot volume of the given instance and
+// waits for the backup to become available.
+func (d *driverOCI) CreateBootVolumeBackup(ctx context.Context, instanceID string) (string, error) {
+	bootVolumeID, err := d.GetInstanceBootVolumeID(ctx, instanceID)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := d.blockstorageClient.CreateBootVolumeBackup(ctx, core.CreateBootVolumeBackupRequest{
+		CreateBootVolumeBackupDetails: core.CreateBootVolumeBackupDetails{
+			BootVolumeId: &bootVolumeID,
+			DisplayName:  &d.cfg.ImageName,
+			Type:         core.CreateBootVolumeBackupDetailsTypeEnum(d.cfg.BootVolumeBackupType),
+			FreeformTags: d.cfg.Tags,
+			DefinedTags:  d.cfg.DefinedTags,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	err = waitForResourceToReachState(
+		ctx,
+		func(id string) (string, error) {
+			res, err := d.blockstorageClient.GetBootVolumeBackup(ctx, core.GetBootVolumeBackupRequest{BootVolumeBackupId: &id, RequestMetadata: requestMetadata})
+			if err != nil {
+				return "", err
+			}
+			return string(res.LifecycleState), nil
+		},
+		*res.Id, []string{"REQUEST_RECEIVED", "CREATING"}, "AVAILABLE", d.cfg.ImageCreateTimeout, 5*time.Second,
+	)
+
+	return *res.Id, err
+}
+
+// DeleteBootVolumeBackup deletes a boot volume backup.
+func (d *driverOCI) DeleteBootVolumeBackup(ctx context.Context, id string) error {
+	_, err := d.blockstorageClient.DeleteBootVolumeBackup(ctx, core.DeleteBootVolumeBackupRequest{
+		BootVolumeBackupId: &id,
+		RequestMetadata:    requestMetadata,
+	})
+
+	return err
+}
+
+// CreateVolume creates a block volume in the build availability domain and
+// waits for it to become available.
+func (d *driverOCI) CreateVolume(ctx context.Context, volume BlockVolume) (string, error) {
+	var displayName *string
+	if volume.DisplayName != "" {
+		displayName = &volume.DisplayName
+	}
+
+	res, err := d.blockstorageClient.CreateVolume(ctx, core.CreateVolumeRequest{
+		CreateVolumeDetails: core.CreateVolumeDetails{
+			AvailabilityDomain: &d.cfg.AvailabilityDomain,
+			CompartmentId:      &d.cfg.CompartmentID,
+			DisplayName:        displayName,
+			SizeInGBs:          &volume.SizeInGBs,
+			VpusPerGB:          volume.VpusPerGB,
+			FreeformTags:       d.cfg.InstanceTags,
+			DefinedTags:        d.cfg.InstanceDefinedTags,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	err = waitForResourceToReachState(
+		ctx,
+		func(id string) (string, error) {
+			res, err := d.blockstorageClient.GetVolume(ctx, core.GetVolumeRequest{VolumeId: &id, RequestMetadata: requestMetadata})
+			if err != nil {
+				return "", err
+			}
+			return string(res.LifecycleState), nil
+		},
+		*res.Id, []string{"PROVISIONING"}, "AVAILABLE", d.cfg.ResourceTimeout, 5*time.Second,
+	)
+
+	return *res.Id, err
+}
+
+// AttachVolume attaches a block volume to the given instance and waits for
+// the attachment to complete.
+func (d *driverOCI) AttachVolume(ctx context.Context, instanceID, volumeID string, volume BlockVolume) (VolumeAttachment, error) {
+	var device *string
+	if volume.Device != "" {
+		device = &volume.Device
+	}
+
+	var details core.AttachVolumeDetails
+	if volume.AttachmentType == "iscsi" {
+		details = core.AttachIScsiVolumeDetails{
+			InstanceId: &instanceID,
+			VolumeId:   &volumeID,
+			Device:     device,
+		}
+	} else {
+		details = core.AttachParavirtualizedVolumeDetails{
+			InstanceId: &instanceID,
+			VolumeId:   &volumeID,
+			Device:     device,
+		}
+	}
+
+	res, err := d.computeClient.AttachVolume(ctx, core.AttachVolumeRequest{
+		AttachVolumeDetails: details,
+		RequestMetadata:     requestMetadata,
+	})
+	if err != nil {
+		return VolumeAttachment{}, err
+	}
+
+	attachment := VolumeAttachment{ID: *res.GetId()}
+	err = waitForResourceToReachState(
+		ctx,
+		func(id string) (string, error) {
+			res, err := d.computeClient.GetVolumeAttachment(ctx, core.GetVolumeAttachmentRequest{VolumeAttachmentId: &id, RequestMetadata: requestMetadata})
+			if err != nil {
+				return "", err
+			}
+			// The iSCSI target is only known once the volume is attached.
+			if iscsi, ok := res.VolumeAttachment.(core.IScsiVolumeAttachment); ok {
+				if iscsi.Iqn != nil {
+					attachment.IQN = *iscsi.Iqn
+				}
+				if iscsi.Ipv4 != nil {
+					attachment.IPv4 = *iscsi.Ipv4
+				}
+				if iscsi.Port != nil {
+					attachment.Port = *iscsi.Port
+				}
+			}
+			return string(res.GetLifecycleState()), nil
+		},
+		attachment.ID, []string{"ATTACHING"}, "ATTACHED", d.cfg.ResourceTimeout, 5*time.Second,
+	)
+
+	return attachment, err
+}
+
+// DetachVolume detaches a block volume and waits for the detachment to
+// complete.
+func (d *driverOCI) DetachVolume(ctx context.Context, attachmentID string) error {
+	_, err := d.computeClient.DetachVolume(ctx, core.DetachVolumeRequest{
+		VolumeAttachmentId: &attachmentID,
+		RequestMetadata:    requestMetadata,
+	})
+	if err != nil {
+		return err
+	}
+
+	return waitForResourceToReachState(
+		ctx,
+		func(id string) (string, error) {
+			res, err := d.computeClient.GetVolumeAttachment(ctx, core.GetVolumeAttachmentRequest{VolumeAttachmentId: &id, RequestMetadata: requestMetadata})
+			if err != nil {
+				return "", err
+			}
+			return string(res.GetLifecycleState()), nil
+		},
+		attachmentID, []string{"ATTACHED", "DETACHING"}, "DETACHED", d.cfg.ResourceTimeout, 5*time.Second,
+	)
+}
+
+// CreateVolumeBackup creates a full backup of a block volume, tagged like the
+// resulting image, and waits for it to become available.
+func (d *driverOCI) CreateVolumeBackup(ctx context.Context, volumeID string) (string, error) {
+	res, err := d.blockstorageClient.CreateVolumeBackup(ctx, core.CreateVolumeBackupRequest{
+		CreateVolumeBackupDetails: core.CreateVolumeBackupDetails{
+			VolumeId:     &volumeID,
+			Type:         core.CreateVolumeBackupDetailsTypeFull,
+			FreeformTags: d.cfg.Tags,
+			DefinedTags:  d.cfg.DefinedTags,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	err = waitForResourceToReachState(
+		ctx,
+		func(id string) (string, error) {
+			res, err := d.blockstorageClient.GetVolumeBackup(ctx, core.GetVolumeBackupRequest{VolumeBackupId: &id, RequestMetadata: requestMetadata})
+			if err != nil {
+				return "", err
+			}
+			return string(res.LifecycleState), nil
+		},
+		*res.Id, []string{"REQUEST_RECEIVED", "CREATING"}, "AVAILABLE", d.cfg.ImageCreateTimeout, 5*time.Second,
+	)
+
+	return *res.Id, err
+}
+
+// DeleteVolumeBackup deletes a block volume backup.
+func (d *driverOCI) DeleteVolumeBackup(ctx context.Context, id string) error {
+	_, err := d.blockstorageClient.DeleteVolumeBackup(ctx, core.DeleteVolumeBackupRequest{
+		VolumeBackupId:  &id,
+		RequestMetadata: requestMetadata,
+	})
+
+	return err
+}
+
+// DeleteVolume deletes a block volume.
+func (d *driverOCI) DeleteVolume(ctx context.Context, volumeID string) error {
+	_, err := d.blockstorageClient.DeleteVolume(ctx, core.DeleteVolumeRequest{
+		VolumeId:        &volumeID,
+		RequestMetadata: requestMetadata,
+	})
+
+	return err
+}
+
+// CreateBastionSession creates a session on the configured bastion that
+// forwards to the given instance and waits for it to become active.
+func (d *driverOCI) CreateBastionSession(ctx context.Context, instanceID, ip string, port int, publicKey string) (BastionSession, error) {
+	var target bastion.CreateSessionTargetResourceDetails
+	if d.cfg.BastionSessionType == "PORT_FORWARDING" {
+		target = bastion.CreatePortForwardingSessionTargetResourceDetails{
+			TargetResourceId:               &instanceID,
+			TargetResourcePrivateIpAddress: &ip,
+			TargetResourcePort:             &port,
+		}
+	} else {
+		target = bastion.CreateManagedSshSessionTargetResourceDetails{
+			TargetResourceId:                      &instanceID,
+			TargetResourceOperatingSystemUserName: &d.cfg.Comm.SSHUsername,
+			TargetResourcePrivateIpAddress:        &ip,
+			TargetResourcePort:                    &port,
+		}
+	}
+
+	displayName := fmt.Sprintf("packer-%s", uuid.TimeOrderedUUID())
+	ttl := int(d.cfg.BastionSessionTTL.Seconds())
+
+	res, err := d.bastionClient.CreateSession(ctx, bastion.CreateSessionRequest{
+		CreateSessionDetails: bastion.CreateSessionDetails{
+			BastionId:             &d.cfg.BastionID,
+			DisplayName:           &displayName,
+			TargetResourceDetails: target,
+			KeyType:               bastion.CreateSessionDetailsKeyTypePub,
+			KeyDetails:            &bastion.PublicKeyDetails{PublicKeyContent: &publicKey},
+			SessionTtlInSeconds:   &ttl,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return BastionSession{}, err
+	}
+
+	session := BastionSession{ID: *res.Id}
+
+	var lifecycleDetails string
+	err = waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			res, err := d.bastionClient.GetSession(ctx, bastion.GetSessionRequest{
+				SessionId:       &session.ID,
+				RequestMetadata: requestMetadata,
+			})
+			if err != nil {
+				return "", err
+			}
+			if res.BastionUserName != nil {
+				session.Username = *res.BastionUserName
+			}
+			if res.LifecycleDetails != nil {
+				lifecycleDetails = *res.LifecycleDetails
+			}
+			return string(res.LifecycleState), nil
+		},
+		session.ID, []string{"CREATING"}, "ACTIVE", d.cfg.ResourceTimeout, 5*time.Second,
+	)
+	if err != nil {
+		if lifecycleDetails != "" {
+			err = fmt.Errorf("%s: %s", err, lifecycleDetails)
+		}
+		return session, err
+	}
+
+	region, err := d.cfg.configProvider.Region()
+	if err != nil {
+		return session, err
+	}
+	session.Host = common.StringToRegion(region).EndpointForTemplate("bastion", "host.bastion.{region}.oci.{secondLevelDomain}")
+
+	return session, nil
+}
+
+// DeleteBastionSession deletes the given bastion session.
+func (d *driverOCI) DeleteBastionSession(ctx context.Context, id string) error {
+	_, err := d.bastionClient.DeleteSession(ctx, bastion.DeleteSessionRequest{
+		SessionId:       &id,
+		RequestMetadata: requestMetadata,
+	})
+
+	return err
+}
+
+// RunInstanceCommand runs a script on an instance through the Run Command
+// plugin of the Oracle Cloud Agent, writes its output to output and returns
+// its exit code. The output is staged in the run_command bucket.
+func (d *driverOCI) RunInstanceCommand(ctx context.Context, instanceID string, script string, output io.Writer) (int, error) {
+	namespace, err := d.GetNamespace(ctx)
+	if err != nil {
+		return 0, err
+	}
+	objectName := fmt.Sprintf("packer-run-command-%s", uuid.TimeOrderedUUID())
+
+	command, err := d.instanceAgentClient.CreateInstanceAgentCommand(ctx, computeinstanceagent.CreateInstanceAgentCommandRequest{
+		CreateInstanceAgentCommandDetails: computeinstanceagent.CreateInstanceAgentCommandDetails{
+			CompartmentId:             &d.cfg.CompartmentID,
+			DisplayName:               common.String(fmt.Sprintf("packer-%s", d.cfg.PackerBuildName)),
+			ExecutionTimeOutInSeconds: common.Int(int(d.cfg.RunCommandTimeout.Seconds())),
+			Target:                    &computeinstanceagent.InstanceAgentCommandTarget{InstanceId: &instanceID},
+			Content: &computeinstanceagent.InstanceAgentCommandContent{
+				Source: computeinstanceagent.InstanceAgentCommandSourceViaTextDetails{Text: &script},
+				Output: computeinstanceagent.InstanceAgentCommandOutputViaObjectStorageTupleDetails{
+					NamespaceName: &namespace,
+					BucketName:    &d.cfg.RunCommandBucketName,
+					ObjectName:    &objectName,
+				},
+			},
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error creating command: %s", err)
+	}
+
+	var execution computeinstanceagent.InstanceAgentCommandExecution
+	err = waitForResourceToReachState(
+		ctx,
+		func(id string) (string, error) {
+			res, err := d.instanceAgentClient.GetInstanceAgentCommandExecution(ctx, computeinstanceagent.GetInstanceAgentCommandExecutionRequest{
+				InstanceAgentCommandId: &id,
+				InstanceId:             &instanceID,
+				RequestMetadata:        requestMetadata,
+			})
+			if err != nil {
+				return "", err
+			}
+			execution = res.InstanceAgentCommandExecution
+
+			switch execution.LifecycleState {
+			case computeinstanceagent.InstanceAgentCommandExecutionLifecycleStateSucceeded,
+				computeinstanceagent.InstanceAgentCommandExecutionLifecycleStateFailed:
+				return "DONE", nil
+			case computeinstanceagent.InstanceAgentCommandExecutionLifecycleStateTimedOut,
+				computeinstanceagent.InstanceAgentCommandExecutionLifecycleStateCanceled:
+				return "", fmt.Errorf("command %s %s", id, strings.ToLower(string(execution.LifecycleState)))
+			}
+			return string(execution.LifecycleState), nil
+		},
+		*command.Id,
+		[]string{"ACCEPTED", "IN_PROGRESS"},
+		"DONE",
+		d.cfg.RunCommandTimeout+5*time.Minute, //the command timeout plus 5 minutes for the agent to pick it up
+		5*time.Second,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	content, ok := execution.Content.(computeinstanceagent.InstanceAgentCommandExecutionOutputViaObjectStorageTupleDetails)
+	if !ok || content.ExitCode == nil {
+		return 0, fmt.Errorf("command %s returned no exit code", *command.Id)
+	}
+
+	// Commands without output don't create the output object.
+	err = d.DownloadObject(ctx, namespace, d.cfg.RunCommandBucketName, objectName, output)
+	var e common.ServiceError
+	if errors.As(err, &e) && e.GetHTTPStatusCode() == http.StatusNotFound {
+		return *content.ExitCode, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error downloading output of command %s: %s", *command.Id, err)
+	}
+
+	if err := d.DeleteObject(ctx, namespace, d.cfg.RunCommandBucketName, objectName); err != nil {
+		log.Printf("[WARN] error deleting output of command %s: %s", *command.Id, err)
+	}
+
+	return *content.ExitCode, nil
+}
+
+// GetConsoleHistory captures the serial console history of an instance and
+// returns its content.
+func (d *driverOCI) GetConsoleHistory(ctx context.Context, instanceID string) (string, error) {
+	capture, err := d.computeClient.CaptureConsoleHistory(ctx, core.CaptureConsoleHistoryRequest{
+		CaptureConsoleHistoryDetails: core.CaptureConsoleHistoryDetails{
+			InstanceId:   &instanceID,
+			FreeformTags: d.cfg.InstanceTags,
+		},
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error capturing console history: %s", err)
+	}
+	defer func() {
+		// The capture is deleted even when ctx was cancelled.
+		ctx, cancel := context.WithTimeout(context.Background(), d.cfg.ResourceTimeout)
+		defer cancel()
+		if _, err := d.computeClient.DeleteConsoleHistory(ctx, core.DeleteConsoleHistoryRequest{
+			InstanceConsoleHistoryId: capture.Id,
+			RequestMetadata:          requestMetadata,
+		}); err != nil {
+			log.Printf("[WARN] error deleting console history %s: %s", *capture.Id, err)
+		}
+	}()
+
+	err = waitForResourceToReachState(
+		ctx,
+		func(id string) (string, error) {
+			res, err := d.computeClient.GetConsoleHistory(ctx, core.GetConsoleHistoryRequest{
+				InstanceConsoleHistoryId: &id,
+				RequestMetadata:          requestMetadata,
+			})
+			if err != nil {
+				return "", err
+			}
+			return string(res.LifecycleState), nil
+		},
+		*capture.Id,
+		[]string{"REQUESTED", "GETTING-HISTORY"},
+		"SUCCEEDED",
+		d.cfg.ResourceTimeout,
+		5*time.Second,
+	)
+	if err != nil {
+		return "", fmt.Errorf("error capturing console history: %s", err)
+	}
+
+	// Captures hold the last megabyte of output.
+	content, err := d.computeClient.GetConsoleHistoryContent(ctx, core.GetConsoleHistoryContentRequest{
+		InstanceConsoleHistoryId: capture.Id,
+		Length:                   common.Int(1024 * 1024),
+		RequestMetadata:          requestMetadata,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error getting console history content: %s", err)
+	}
+	if content.Value == nil {
+		return "", nil
+	}
+
+	return *content.Value, nil
+}
+
+// GetInstanceIPs returns the private and public IP of the primary VNIC of the
+// given instance. The public IP is empty when the instance has none.
+func (d *driverOCI) GetInstanceIPs(ctx context.Context, id string) (string, string, error) {
+	vnics, err := d.computeClient.ListVnicAttachments(ctx, core.ListVnicAttachmentsRequest{
+		InstanceId:      &id,
+		CompartmentId:   &d.cfg.CompartmentID,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(vnics.Items) == 0 {
+		return "", "", errors.New("instance has zero VNICs")
+	}
+
+	vnic, err := d.vcnClient.GetVnic(ctx, core.GetVnicRequest{
+		VnicId:          vnics.Items[0].VnicId,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("error getting VNIC details: %s", err)
+	}
+
+	var publicIP string
+	if vnic.PublicIp != nil {
+		publicIP = *vnic.PublicIp
+	}
+
+	return *vnic.PrivateIp, publicIP, nil
+}
+
+// GetInstanceInitialCredentials returns the username and password Windows
+// generated for the instance, waiting until they are available.
+func (d *driverOCI) GetInstanceInitialCredentials(ctx context.Context, id string) (string, string, error) {
+	var credentials core.GetWindowsInstanceInitialCredentialsResponse
+	err := waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			var err error
+			credentials, err = d.computeClient.GetWindowsInstanceInitialCredentials(ctx, core.GetWindowsInstanceInitialCredentialsRequest{
+				InstanceId:      &id,
+				RequestMetadata: requestMetadata,
+			})
+			// The credentials only exist once Windows finished its first boot.
+			var e common.ServiceError
+			if errors.As(err, &e) && (e.GetHTTPStatusCode() == http.StatusNotFound || e.GetHTTPStatusCode() == http.StatusConflict) {
+				return "PENDING", nil
+			}
+			if err != nil {
+				return "", err
+			}
+			return "AVAILABLE", nil
+		},
+		id,
+		[]string{"PENDING"},
+		"AVAILABLE",
+		d.cfg.InstanceLaunchTimeout,
+		10*time.Second,
+	)
+	if err != nil {
+		return "", "", err
+	}
+
+	return *credentials.InstanceCredentials.Username, *credentials.InstanceCredentials.Password, nil
+}
+
+// TerminateInstance terminates a compute instance.
+func (d *driverOCI) TerminateInstance(ctx context.Context, id string) error {
+	_, err := d.computeClient.TerminateInstance(ctx, core.TerminateInstanceRequest{
+		InstanceId:      &id,
+		RequestMetadata: requestMetadata,
+	})
+	return err
+}
+
+// WaitForImageCreation waits for a provisioning custom image to reach the
+// "AVAILABLE" state.
+func (d *driverOCI) WaitForImageCreation(ctx context.Context, id string) error {
+	return waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			image, err := d.computeClient.GetImage(ctx, core.GetImageRequest{
+				ImageId:         &id,
+				RequestMetadata: requestMetadata,
+			})
+			if err != nil {
+				return "", err
+			}
+			return string(image.LifecycleState), nil
+		},
+		id,
+		[]string{"PROVISIONING"},
+		"AVAILABLE",
+		d.cfg.ImageCreateTimeout,
+		5*time.Second,
+	)
+}
+
+// GetInstanceState returns the lifecycle state of an instance.
+func (d *driverOCI) GetInstanceState(ctx context.Context, id string) (string, error) {
+	instance, err := d.computeClient.GetInstance(ctx, core.GetInstanceRequest{
+		InstanceId:      &id,
+		RequestMetadata: requestMetadata,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(instance.LifecycleState), nil
+}
+
+// WaitForInstanceState waits for an instance to reach the a given terminal
+// state, for at most timeout unless it is 0.
+func (d *driverOCI) WaitForInstanceState(ctx context.Context, id string, waitStates []string, terminalState string, timeout time.Duration) error {
+	return waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			state, err := d.GetInstanceState(ctx, id)
+			if err != nil {
+				return "", err
+			}
+			if terminalState != "TERMINATED" && (state == "TERMINATING" || state == "TERMINATED") {
+				return "", errInstanceTerminated
+			}
+			return state, nil
+		},
+		id,
+		waitStates,
+		terminalState,
+		timeout,
+		5*time.Second,
+	)
+}
+
+// waitForWorkRequest waits for an asynchronous work request to succeed.
+func (d *driverOCI) waitForWorkRequest(ctx context.Context, id string) error {
+	return waitForResourceToReachState(
+		ctx,
+		func(string) (string, error) {
+			workRequest, err := d.workRequestClient.GetWorkRequest(ctx, workrequests.GetWorkRequestRequest{
+				WorkRequestId:   &id,
+				RequestMetadata: requestMetadata,
+			})
+			if err != nil {
+				return "", err
+			}
+			return string(workRequest.Status), nil
+		},
+		id,
+		[]string{"ACCEPTED", "IN_PROGRESS"},
+		"SUCCEEDED",
+		d.cfg.ImageCreateTimeout,
+		5*time.Second,
+	)
+}
+
+// maxWaitDuration caps the backoff between two polls of a resource.
+const maxWaitDuration = 30 * time.Second
+
+// waitForResourceToReachState polls the state of a resource until it reaches
+// terminalState, waiting waitDuration between polls at first and backing off
+// up to maxWaitDuration. It gives up once timeout elapsed, unless it is 0, or
+// once ctx is canceled.
+func waitForResourceToReachState(ctx context.Context, getResourceState func(string) (string, error), id string, waitStates []string, terminalState string, timeout time.Duration, waitDuration time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	for {
+		state, err := getResourceState(id)
+		if err != nil {
+			return err
+		}
+
+		if state == terminalState {
+			return nil
+		}
+		if !stringSliceContains(waitStates, state) {
+			return fmt.Errorf("unexpected state %q for resource %s, expecting a waiting state %s or terminal state %q", state, id, waitStates, terminalState)
+		}
+
+		timer := time.NewTimer(waitDuration)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timed out after %s waiting for resource %s to reach state %q, last state %q", timeout, id, terminalState, state)
+			}
+			return fmt.Errorf("canceled waiting for resource %s to reach state %q, last state %q: %w", id, terminalState, state, ctx.Err())
+		case <-timer.C:
+		}
+
+		waitDuration = min(waitDuration*3/2, max(waitDuration, maxWaitDuration))
 	}
-	return fmt.Errorf("maximum number of retries (%d) exceeded; resource did not reach state %q", maxRetries, terminalState)
 }
 
 // stringSliceContains loops through a slice of strings returning a boolean
@@ -0,0 +1,70 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testResourceStates returns a getter reporting the given states in turn,
+// the last one for ever.
+func testResourceStates(states ...string) func(string) (string, error) {
+	return func(string) (string, error) {
+		state := states[0]
+		if len(states) > 1 {
+			states = states[1:]
+		}
+		return state, nil
+	}
+}
+
+func TestWaitForResourceToReachState(t *testing.T) {
+	err := waitForResourceToReachState(context.Background(),
+		testResourceStates("PROVISIONING", "PROVISIONING", "AVAILABLE"),
+		"ocid1.image.aaa", []string{"PROVISIONING"}, "AVAILABLE", time.Minute, time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestWaitForResourceToReachState_UnexpectedState(t *testing.T) {
+	err := waitForResourceToReachState(context.Background(),
+		testResourceStates("PROVISIONING", "FAILED"),
+		"ocid1.image.aaa", []string{"PROVISIONING"}, "AVAILABLE", 0, time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), `unexpected state "FAILED" for resource ocid1.image.aaa`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWaitForResourceToReachState_Timeout(t *testing.T) {
+	err := waitForResourceToReachState(context.Background(),
+		testResourceStates("PROVISIONING"),
+		"ocid1.image.aaa", []string{"PROVISIONING"}, "AVAILABLE", 20*time.Millisecond, time.Millisecond)
+	if err == nil {
+		t.Fatal("expected a timeout")
+	}
+	expected := `timed out after 20ms waiting for resource ocid1.image.aaa to reach state "AVAILABLE", last state "PROVISIONING"`
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err)
+	}
+}
+
+func TestWaitForResourceToReachState_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := waitForResourceToReachState(ctx,
+		testResourceStates("PROVISIONING"),
+		"ocid1.image.aaa", []string{"PROVISIONING"}, "AVAILABLE", 0, time.Hour)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the wait to be canceled, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `last state "PROVISIONING"`) {
+		t.Errorf("error should name the last state: %s", err)
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/tmp"
+	"github.com/hashicorp/packer-plugin-sdk/uuid"
+)
+
+// runCommandCommunicator is a packersdk.Communicator running commands through
+// the Run Command plugin of the Oracle Cloud Agent, so no inbound network
+// access to the instance is needed. Files are staged in an Object Storage
+// bucket and transferred by the instance with curl over pre-authenticated
+// requests. Commands run with /bin/sh as the ocarun user.
+type runCommandCommunicator struct {
+	driver     Driver
+	instanceID string
+	namespace  string
+	bucket     string
+	// timeout limits how long pre-authenticated requests stay valid.
+	timeout time.Duration
+}
+
+var _ packersdk.Communicator = new(runCommandCommunicator)
+
+// Start runs the command in the background. Its combined output is written
+// to the command's stdout once it finished, as Run Command doesn't stream
+// output.
+func (c *runCommandCommunicator) Start(ctx context.Context, cmd *packersdk.RemoteCmd) error {
+	go func() {
+		var output bytes.Buffer
+		exitCode, err := c.driver.RunInstanceCommand(ctx, c.instanceID, cmd.Command, &output)
+		if err != nil {
+			if cmd.Stderr != nil {
+				fmt.Fprintf(cmd.Stderr, "Error running command: %s\n", err)
+			}
+			cmd.SetExited(packersdk.CmdDisconnect)
+			return
+		}
+
+		if cmd.Stdout != nil {
+			if _, err := io.Copy(cmd.Stdout, &output); err != nil {
+				log.Printf("[WARN] error writing command output: %s", err)
+			}
+		}
+		cmd.SetExited(exitCode)
+	}()
+
+	return nil
+}
+
+// Upload stages the content in Object Storage and downloads it to dst on the
+// instance.
+func (c *runCommandCommunicator) Upload(dst string, r io.Reader, fi *os.FileInfo) error {
+	ctx := context.TODO()
+
+	objectName, err := c.stage(ctx, func(w io.Writer) error {
+		_, err := io.Copy(w, r)
+		return err
+	})
+	if objectName != "" {
+		defer c.deleteObject(ctx, objectName)
+	}
+	if err != nil {
+		return err
+	}
+
+	url, err := c.driver.CreateObjectReadURI(ctx, c.namespace, c.bucket, objectName, time.Now().Add(c.timeout))
+	if err != nil {
+		return err
+	}
+
+	script := fmt.Sprintf("curl -fsSL -o %s %s", shellQuote(dst), shellQuote(url))
+	if fi != nil {
+		script += fmt.Sprintf(" && chmod %o %s", (*fi).Mode().Perm(), shellQuote(dst))
+	}
+	return c.run(ctx, script)
+}
+
+// UploadDir uploads the directory src to dst. Like the other communicators,
+// the content of src is uploaded when src ends with a slash, and src itself
+// otherwise. Paths in src matching one of the exclude patterns are skipped.
+func (c *runCommandCommunicator) UploadDir(dst string, src string, exclude []string) error {
+	ctx := context.TODO()
+
+	prefix := ""
+	if !strings.HasSuffix(src, "/") {
+		prefix = filepath.Base(src)
+	}
+
+	objectName, err := c.stage(ctx, func(w io.Writer) error {
+		return writeTarGz(w, src, prefix, exclude)
+	})
+	if objectName != "" {
+		defer c.deleteObject(ctx, objectName)
+	}
+	if err != nil {
+		return err
+	}
+
+	url, err := c.driver.CreateObjectReadURI(ctx, c.namespace, c.bucket, objectName, time.Now().Add(c.timeout))
+	if err != nil {
+		return err
+	}
+
+	return c.run(ctx, fmt.Sprintf("mkdir -p %s && curl -fsSL %s | tar -xzf - -C %s", shellQuote(dst), shellQuote(url), shellQuote(dst)))
+}
+
+// Download uploads src from the instance to Object Storage and writes its
+// content to w.
+func (c *runCommandCommunicator) Download(src string, w io.Writer) error {
+	ctx := context.TODO()
+
+	objectName := fmt.Sprintf("packer-download-%s", uuid.TimeOrderedUUID())
+	url, err := c.driver.CreateObjectWriteURI(ctx, c.namespace, c.bucket, objectName, time.Now().Add(c.timeout))
+	if err != nil {
+		return err
+	}
+
+	if err := c.run(ctx, fmt.Sprintf("curl -fsS -X PUT --upload-file %s %s", shellQuote(src), shellQuote(url))); err != nil {
+		return err
+	}
+	defer c.deleteObject(ctx, objectName)
+
+	return c.driver.DownloadObject(ctx, c.namespace, c.bucket, objectName, w)
+}
+
+func (c *runCommandCommunicator) DownloadDir(src string, dst string, exclude []string) error {
+	return errors.New("DownloadDir is not supported by the run_command communicator")
+}
+
+// run runs a script and fails if it exits with a non-zero status.
+func (c *runCommandCommunicator) run(ctx context.Context, script string) error {
+	var output bytes.Buffer
+	exitCode, err := c.driver.RunInstanceCommand(ctx, c.instanceID, script, &output)
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("command exited with status %d: %s", exitCode, strings.TrimSpace(output.String()))
+	}
+
+	return nil
+}
+
+// stage writes content to a temporary file and uploads it to a new object in
+// the bucket, returning the name of the object.
+func (c *runCommandCommunicator) stage(ctx context.Context, write func(io.Writer) error) (string, error) {
+	f, err := tmp.File("oci-run-command")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+
+	err = write(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("error staging upload: %s", err)
+	}
+
+	objectName := fmt.Sprintf("packer-upload-%s", uuid.TimeOrderedUUID())
+	if err := c.driver.UploadObject(ctx, c.namespace, c.bucket, objectName, f.Name()); err != nil {
+		return "", fmt.Errorf("error uploading to bucket %s: %s", c.bucket, err)
+	}
+
+	return objectName, nil
+}
+
+func (c *runCommandCommunicator) deleteObject(ctx context.Context, objectName string) {
+	if err := c.driver.DeleteObject(ctx, c.namespace, c.bucket, objectName); err != nil {
+		log.Printf("[WARN] error deleting staged object %s from bucket %s: %s", objectName, c.bucket, err)
+	}
+}
+
+// writeTarGz writes a gzipped tarball of the directory src to w, with every
+// path below prefix.
+func writeTarGz(w io.Writer, src string, prefix string, exclude []string) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(filepath.Join(prefix, rel))
+		if name == "." {
+			return nil
+		}
+
+		excluded, err := excludedPath(filepath.ToSlash(rel), exclude)
+		if err != nil {
+			return err
+		}
+		if excluded {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// excludedPath reports whether the slash separated path rel, relative to the
+// uploaded directory, or its base name matches one of the exclude patterns.
+func excludedPath(rel string, exclude []string) (bool, error) {
+	if rel == "." {
+		return false, nil
+	}
+	for _, pattern := range exclude {
+		for _, name := range []string{rel, path.Base(rel)} {
+			match, err := path.Match(pattern, name)
+			if err != nil {
+				return false, fmt.Errorf("bad exclude pattern %q: %s", pattern, err)
+			}
+			if match {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// shellQuote quotes s for /bin/sh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,203 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+func testRunCommandCommunicator() (*runCommandCommunicator, *driverMock) {
+	driver := &driverMock{}
+	return &runCommandCommunicator{
+		driver:     driver,
+		instanceID: "ocid1.instance.oc1.phx.aaa",
+		namespace:  "namespace",
+		bucket:     "bucket",
+		timeout:    time.Hour,
+	}, driver
+}
+
+func TestRunCommandCommunicator_Start(t *testing.T) {
+	comm, driver := testRunCommandCommunicator()
+	driver.RunInstanceCommandOutput = "hello\n"
+	driver.RunInstanceCommandExitCode = 3
+
+	var stdout bytes.Buffer
+	cmd := &packersdk.RemoteCmd{Command: "echo hello", Stdout: &stdout}
+	if err := comm.Start(context.Background(), cmd); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if status := cmd.Wait(); status != 3 {
+		t.Fatalf("bad exit status: %d", status)
+	}
+	if stdout.String() != "hello\n" {
+		t.Fatalf("bad output: %q", stdout.String())
+	}
+	if driver.RunInstanceCommandScripts[0] != "echo hello" {
+		t.Fatalf("bad script: %s", driver.RunInstanceCommandScripts[0])
+	}
+}
+
+func TestRunCommandCommunicator_StartError(t *testing.T) {
+	comm, driver := testRunCommandCommunicator()
+	driver.RunInstanceCommandErr = errors.New("command timed_out")
+
+	var stderr bytes.Buffer
+	cmd := &packersdk.RemoteCmd{Command: "sleep 1000", Stderr: &stderr}
+	if err := comm.Start(context.Background(), cmd); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if status := cmd.Wait(); status != packersdk.CmdDisconnect {
+		t.Fatalf("bad exit status: %d", status)
+	}
+	if !strings.Contains(stderr.String(), "command timed_out") {
+		t.Fatalf("bad error output: %q", stderr.String())
+	}
+}
+
+func TestRunCommandCommunicator_Upload(t *testing.T) {
+	comm, driver := testRunCommandCommunicator()
+
+	if err := comm.Upload("/tmp/it's.sh", strings.NewReader("#!/bin/sh"), nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if driver.UploadObjectData != "#!/bin/sh" {
+		t.Fatalf("bad uploaded data: %q", driver.UploadObjectData)
+	}
+	if driver.CreateObjectReadURIObject != driver.UploadObjectName || driver.DeleteObjectName != driver.UploadObjectName {
+		t.Fatalf("staged object %s was not shared and deleted", driver.UploadObjectName)
+	}
+	expected := `curl -fsSL -o '/tmp/it'\''s.sh' 'https://objectstorage/p/par/n/namespace/b/bucket/o/` + driver.UploadObjectName + `'`
+	if driver.RunInstanceCommandScripts[0] != expected {
+		t.Fatalf("bad script: %s", driver.RunInstanceCommandScripts[0])
+	}
+}
+
+func TestRunCommandCommunicator_UploadFailure(t *testing.T) {
+	comm, driver := testRunCommandCommunicator()
+	driver.RunInstanceCommandOutput = "curl: (6) Could not resolve host"
+	driver.RunInstanceCommandExitCode = 6
+
+	err := comm.Upload("/tmp/script.sh", strings.NewReader("#!/bin/sh"), nil)
+	if err == nil || !strings.Contains(err.Error(), "Could not resolve host") {
+		t.Fatalf("bad error: %v", err)
+	}
+	if driver.DeleteObjectName != driver.UploadObjectName {
+		t.Fatalf("staged object %s was not deleted", driver.UploadObjectName)
+	}
+}
+
+func TestRunCommandCommunicator_UploadDir(t *testing.T) {
+	src := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(src, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "sub", "file"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	comm, driver := testRunCommandCommunicator()
+	if err := comm.UploadDir("/opt/files", src, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	names := tarGzNames(t, driver.UploadObjectData)
+
+	base := filepath.Base(src)
+	expected := []string{base, base + "/sub", base + "/sub/file"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("bad archive content: %v instead of %v", names, expected)
+	}
+	if !strings.HasPrefix(driver.RunInstanceCommandScripts[0], "mkdir -p '/opt/files' && curl -fsSL ") {
+		t.Fatalf("bad script: %s", driver.RunInstanceCommandScripts[0])
+	}
+}
+
+func TestRunCommandCommunicator_UploadDirExclude(t *testing.T) {
+	src := t.TempDir()
+	for _, dir := range []string{"keep", ".git"} {
+		if err := os.MkdirAll(filepath.Join(src, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, file := range []string{"keep/file", "keep/file.log", ".git/config"} {
+		if err := os.WriteFile(filepath.Join(src, file), []byte("data"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	comm, driver := testRunCommandCommunicator()
+	if err := comm.UploadDir("/opt/files", src+"/", []string{".git", "*.log"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	names := tarGzNames(t, driver.UploadObjectData)
+	expected := []string{"keep", "keep/file"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("bad archive content: %v instead of %v", names, expected)
+	}
+
+	if err := comm.UploadDir("/opt/files", src, []string{"["}); err == nil {
+		t.Fatalf("should fail on a bad exclude pattern")
+	}
+}
+
+func TestRunCommandCommunicator_Download(t *testing.T) {
+	comm, driver := testRunCommandCommunicator()
+	driver.DownloadObjectData = "log"
+
+	var w bytes.Buffer
+	if err := comm.Download("/var/log/build.log", &w); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if w.String() != "log" {
+		t.Fatalf("bad downloaded data: %q", w.String())
+	}
+	if driver.DownloadObjectName != driver.CreateObjectWriteURIObject || driver.DeleteObjectName != driver.CreateObjectWriteURIObject {
+		t.Fatalf("staged object %s was not shared and deleted", driver.CreateObjectWriteURIObject)
+	}
+	if !strings.HasPrefix(driver.RunInstanceCommandScripts[0], "curl -fsS -X PUT --upload-file '/var/log/build.log' ") {
+		t.Fatalf("bad script: %s", driver.RunInstanceCommandScripts[0])
+	}
+}
+
+// tarGzNames returns the sorted names of the entries of a gzipped tarball.
+func tarGzNames(t *testing.T, data string) []string {
+	gz, err := gzip.NewReader(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("bad archive: %s", err)
+	}
+	var names []string
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("bad archive: %s", err)
+		}
+		names = append(names, header.Name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,126 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/packerbuilderdata"
+)
+
+// attachedVolume tracks a block volume created for the build instance.
+type attachedVolume struct {
+	VolumeID     string
+	AttachmentID string
+	KeepBackup   bool
+}
+
+// stepAttachVolumes creates the configured block volumes and attaches them to
+// the build instance. The commands that log the instance in to iSCSI
+// attachments are exposed as the ISCSILoginCommands generated data. The
+// volumes are put in the state for stepBackupVolumes, and deleted on cleanup.
+type stepAttachVolumes struct {
+	volumes []*attachedVolume
+}
+
+func (s *stepAttachVolumes) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	var loginCommands []string
+
+	for i, volume := range config.BlockVolumes {
+		ui.Say(fmt.Sprintf("Creating block volume %d (%d GBs)...", i, volume.SizeInGBs))
+
+		volumeID, err := driver.CreateVolume(ctx, volume)
+		if volumeID != "" {
+			s.volumes = append(s.volumes, &attachedVolume{VolumeID: volumeID, KeepBackup: volume.KeepBackup})
+		}
+		if err != nil {
+			err = fmt.Errorf("Error creating block volume: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+
+		ui.Say(fmt.Sprintf("Attaching block volume %s as %s...", volumeID, volume.AttachmentType))
+
+		attachment, err := driver.AttachVolume(ctx, id, volumeID, volume)
+		if attachment.ID != "" {
+			s.volumes[len(s.volumes)-1].AttachmentID = attachment.ID
+		}
+		if err != nil {
+			err = fmt.Errorf("Error attaching block volume %s: %s", volumeID, err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+
+		if attachment.IQN != "" {
+			commands := iscsiLoginCommands(attachment)
+			ui.Message(fmt.Sprintf("Log the instance in to block volume %s with:\n%s", volumeID, strings.Join(commands, "\n")))
+			loginCommands = append(loginCommands, commands...)
+		}
+	}
+
+	generatedData := &packerbuilderdata.GeneratedData{State: state}
+	generatedData.Put("ISCSILoginCommands", strings.Join(loginCommands, "\n"))
+
+	state.Put("block_volumes", s.volumes)
+
+	return multistep.ActionContinue
+}
+
+// iscsiLoginCommands returns the commands that log an instance in to an iSCSI
+// volume attachment and log it back in on boot.
+func iscsiLoginCommands(attachment VolumeAttachment) []string {
+	portal := fmt.Sprintf("%s:%d", attachment.IPv4, attachment.Port)
+
+	return []string{
+		fmt.Sprintf("sudo iscsiadm -m node -o new -T %s -p %s", attachment.IQN, portal),
+		fmt.Sprintf("sudo iscsiadm -m node -o update -T %s -n node.startup -v automatic", attachment.IQN),
+		fmt.Sprintf("sudo iscsiadm -m node -T %s -p %s -l", attachment.IQN, portal),
+	}
+}
+
+func (s *stepAttachVolumes) Cleanup(state multistep.StateBag) {
+	if len(s.volumes) == 0 {
+		return
+	}
+
+	driver := state.Get("driver").(Driver)
+	ui := state.Get("ui").(packersdk.Ui)
+	config := state.Get("config").(*Config)
+
+	for i := len(s.volumes) - 1; i >= 0; i-- {
+		volume := s.volumes[i]
+
+		if volume.AttachmentID != "" {
+			ui.Say(fmt.Sprintf("Detaching block volume %s...", volume.VolumeID))
+			ctx, cancel := context.WithTimeout(context.Background(), config.ResourceTimeout)
+			err := driver.DetachVolume(ctx, volume.AttachmentID)
+			cancel()
+			if err != nil {
+				ui.Error(fmt.Sprintf("Error detaching block volume %s. Please delete it manually: %s", volume.VolumeID, err))
+				continue
+			}
+		}
+
+		ui.Say(fmt.Sprintf("Deleting block volume %s...", volume.VolumeID))
+		ctx, cancel := context.WithTimeout(context.Background(), config.ResourceTimeout)
+		err := driver.DeleteVolume(ctx, volume.VolumeID)
+		cancel()
+		if err != nil {
+			ui.Error(fmt.Sprintf("Error deleting block volume %s. Please delete it manually: %s", volume.VolumeID, err))
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepAttachVolumes(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	config := state.Get("config").(*Config)
+	config.BlockVolumes = []BlockVolume{
+		{SizeInGBs: 50, AttachmentType: "paravirtualized"},
+		{SizeInGBs: 100, AttachmentType: "iscsi", KeepBackup: true},
+	}
+
+	step := new(stepAttachVolumes)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if !reflect.DeepEqual(driver.CreateVolumeSizes, []int64{50, 100}) {
+		t.Fatalf("bad volume sizes: %v", driver.CreateVolumeSizes)
+	}
+
+	if driver.AttachVolumeInstanceID != "ocid1..." {
+		t.Fatalf("should've attached volumes to the instance")
+	}
+
+	generatedData := state.Get("generated_data").(map[string]interface{})
+	expected := "sudo iscsiadm -m node -o new -T iqn.2015-12.com.oracleiaas:2 -p 169.254.2.2:3260\n" +
+		"sudo iscsiadm -m node -o update -T iqn.2015-12.com.oracleiaas:2 -n node.startup -v automatic\n" +
+		"sudo iscsiadm -m node -T iqn.2015-12.com.oracleiaas:2 -p 169.254.2.2:3260 -l"
+	if commands := generatedData["ISCSILoginCommands"]; commands != expected {
+		t.Fatalf("bad iSCSI login commands: %v", commands)
+	}
+
+	if volumes := state.Get("block_volumes").([]*attachedVolume); len(volumes) != 2 || !volumes[1].KeepBackup {
+		t.Fatalf("bad block volumes state: %v", volumes)
+	}
+
+	step.Cleanup(state)
+
+	if !reflect.DeepEqual(driver.DetachVolumeIDs, []string{"ocid1.volumeattachment...2", "ocid1.volumeattachment...1"}) {
+		t.Fatalf("bad detached volumes: %v", driver.DetachVolumeIDs)
+	}
+
+	if len(driver.CreateVolumeBackupIDs) != 0 {
+		t.Fatalf("should leave backups to stepBackupVolumes: %v", driver.CreateVolumeBackupIDs)
+	}
+
+	if !reflect.DeepEqual(driver.DeleteVolumeIDs, []string{"ocid1.volume...2", "ocid1.volume...1"}) {
+		t.Fatalf("bad deleted volumes: %v", driver.DeleteVolumeIDs)
+	}
+}
+
+func TestStepAttachVolumes_AttachVolumeErr(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	config := state.Get("config").(*Config)
+	config.BlockVolumes = []BlockVolume{{SizeInGBs: 50, AttachmentType: "paravirtualized", KeepBackup: true}}
+
+	step := new(stepAttachVolumes)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.AttachVolumeErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+
+	state.Put(multistep.StateHalted, true)
+	step.Cleanup(state)
+
+	if len(driver.DetachVolumeIDs) != 0 {
+		t.Fatalf("should not have detached volumes: %v", driver.DetachVolumeIDs)
+	}
+
+	if _, ok := state.GetOk("block_volumes"); ok {
+		t.Fatalf("should not have put block volumes of a failed step")
+	}
+
+	if !reflect.DeepEqual(driver.DeleteVolumeIDs, []string{"ocid1.volume...1"}) {
+		t.Fatalf("should've deleted the created volume: %v", driver.DeleteVolumeIDs)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepBackupVolumes detaches the block volumes created with keep_backup and
+// backs them up once the image was created, so a failed backup fails the
+// build. The backups are deleted when a later step fails.
+type stepBackupVolumes struct {
+	backupIDs []string
+}
+
+func (s *stepBackupVolumes) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+	)
+
+	rawVolumes, ok := state.GetOk("block_volumes")
+	if !ok {
+		return multistep.ActionContinue
+	}
+
+	for _, volume := range rawVolumes.([]*attachedVolume) {
+		if !volume.KeepBackup {
+			continue
+		}
+
+		// Detaching first makes sure everything written to the volume is in
+		// the backup.
+		if volume.AttachmentID != "" {
+			ui.Say(fmt.Sprintf("Detaching block volume %s...", volume.VolumeID))
+			if err := driver.DetachVolume(ctx, volume.AttachmentID); err != nil {
+				err = fmt.Errorf("Error detaching block volume %s: %s", volume.VolumeID, err)
+				ui.Error(err.Error())
+				state.Put("error", err)
+				return multistep.ActionHalt
+			}
+			volume.AttachmentID = ""
+		}
+
+		ui.Say(fmt.Sprintf("Backing up block volume %s...", volume.VolumeID))
+		id, err := driver.CreateVolumeBackup(ctx, volume.VolumeID)
+		if id != "" {
+			s.backupIDs = append(s.backupIDs, id)
+		}
+		if err != nil {
+			err = fmt.Errorf("Error backing up block volume %s: %s", volume.VolumeID, err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+		ui.Say(fmt.Sprintf("Created block volume backup (%s).", id))
+	}
+
+	if len(s.backupIDs) > 0 {
+		state.Put("volume_backup_ids", s.backupIDs)
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *stepBackupVolumes) Cleanup(state multistep.StateBag) {
+	if len(s.backupIDs) == 0 {
+		return
+	}
+
+	_, cancelled := state.GetOk(multistep.StateCancelled)
+	_, halted := state.GetOk(multistep.StateHalted)
+	if !cancelled && !halted {
+		return
+	}
+
+	driver := state.Get("driver").(Driver)
+	ui := state.Get("ui").(packersdk.Ui)
+	config := state.Get("config").(*Config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.ResourceTimeout)
+	defer cancel()
+
+	for _, id := range s.backupIDs {
+		ui.Say(fmt.Sprintf("Deleting block volume backup (%s)...", id))
+		if err := driver.DeleteVolumeBackup(ctx, id); err != nil {
+			ui.Error(fmt.Sprintf("Error deleting block volume backup %s. Please delete it manually: %s", id, err))
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func testBackupVolumesState() (multistep.StateBag, *driverMock) {
+	state := testState()
+	state.Put("block_volumes", []*attachedVolume{
+		{VolumeID: "ocid1.volume...1", AttachmentID: "ocid1.volumeattachment...1"},
+		{VolumeID: "ocid1.volume...2", AttachmentID: "ocid1.volumeattachment...2", KeepBackup: true},
+	})
+	return state, state.Get("driver").(*driverMock)
+}
+
+func TestStepBackupVolumes(t *testing.T) {
+	state, driver := testBackupVolumesState()
+
+	step := new(stepBackupVolumes)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if !reflect.DeepEqual(driver.DetachVolumeIDs, []string{"ocid1.volumeattachment...2"}) {
+		t.Fatalf("should've detached the volume to back up: %v", driver.DetachVolumeIDs)
+	}
+	if !reflect.DeepEqual(driver.CreateVolumeBackupIDs, []string{"ocid1.volume...2"}) {
+		t.Fatalf("bad backed up volumes: %v", driver.CreateVolumeBackupIDs)
+	}
+	if ids := state.Get("volume_backup_ids"); !reflect.DeepEqual(ids, []string{"ocid1.volumebackup...1"}) {
+		t.Fatalf("bad volume backups: %v", ids)
+	}
+
+	volumes := state.Get("block_volumes").([]*attachedVolume)
+	if volumes[1].AttachmentID != "" || volumes[0].AttachmentID == "" {
+		t.Fatalf("only the backed up volume should be marked as detached: %v", volumes)
+	}
+
+	step.Cleanup(state)
+	if len(driver.DeleteVolumeBackupIDs) != 0 {
+		t.Fatalf("should NOT have deleted the backups of a successful build")
+	}
+}
+
+func TestStepBackupVolumes_CreateVolumeBackupErr(t *testing.T) {
+	state, driver := testBackupVolumesState()
+	driver.CreateVolumeBackupErr = errors.New("error")
+
+	step := new(stepBackupVolumes)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+	if _, ok := state.GetOk("volume_backup_ids"); ok {
+		t.Fatalf("should not have volume backups")
+	}
+}
+
+func TestStepBackupVolumesCleanup_Halted(t *testing.T) {
+	state, driver := testBackupVolumesState()
+
+	step := new(stepBackupVolumes)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	state.Put(multistep.StateHalted, true)
+	step.Cleanup(state)
+
+	if !reflect.DeepEqual(driver.DeleteVolumeBackupIDs, []string{"ocid1.volumebackup...1"}) {
+		t.Fatalf("should've deleted the volume backups: %v", driver.DeleteVolumeBackupIDs)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepBootVolumeBackup backs up the boot volume of the build instance before
+// it gets terminated.
+type stepBootVolumeBackup struct {
+	Skip bool
+}
+
+func (s *stepBootVolumeBackup) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver     = state.Get("driver").(Driver)
+		ui         = state.Get("ui").(packersdk.Ui)
+		instanceID = state.Get("instance_id").(string)
+	)
+
+	if s.Skip {
+		return multistep.ActionContinue
+	}
+
+	ui.Say("Creating boot volume backup...")
+
+	id, err := driver.CreateBootVolumeBackup(ctx, instanceID)
+	if id != "" {
+		state.Put("boot_volume_backup_id", id)
+	}
+	if err != nil {
+		err = fmt.Errorf("Error creating boot volume backup: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	ui.Say(fmt.Sprintf("Created boot volume backup (%s).", id))
+
+	return multistep.ActionContinue
+}
+
+func (s *stepBootVolumeBackup) Cleanup(state multistep.StateBag) {
+	_, cancelled := state.GetOk(multistep.StateCancelled)
+	_, halted := state.GetOk(multistep.StateHalted)
+	if !cancelled && !halted {
+		return
+	}
+
+	id, ok := state.GetOk("boot_volume_backup_id")
+	if !ok {
+		return
+	}
+
+	driver := state.Get("driver").(Driver)
+	ui := state.Get("ui").(packersdk.Ui)
+	config := state.Get("config").(*Config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.ResourceTimeout)
+	defer cancel()
+
+	ui.Say(fmt.Sprintf("Deleting boot volume backup (%s)...", id))
+	if err := driver.DeleteBootVolumeBackup(ctx, id.(string)); err != nil {
+		ui.Error(fmt.Sprintf("Error deleting boot volume backup %s. Please delete it manually: %s", id, err))
+	}
+}
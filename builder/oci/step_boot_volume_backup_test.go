@@ -0,0 +1,102 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepBootVolumeBackup(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	step := new(stepBootVolumeBackup)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.CreateBootVolumeBackupInstanceID != "ocid1..." {
+		t.Fatalf("should've backed up the instance's boot volume")
+	}
+
+	id, ok := state.GetOk("boot_volume_backup_id")
+	if !ok || id.(string) != "ocid1.bootvolumebackup..." {
+		t.Fatalf("should have boot_volume_backup_id, got %v", id)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteBootVolumeBackupID != "" {
+		t.Fatalf("should not have deleted the backup of a successful build")
+	}
+}
+
+func TestStepBootVolumeBackup_skip(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	step := &stepBootVolumeBackup{Skip: true}
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.CreateBootVolumeBackupInstanceID != "" {
+		t.Fatalf("should not have created a boot volume backup")
+	}
+}
+
+func TestStepBootVolumeBackup_CreateBootVolumeBackupErr(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	step := new(stepBootVolumeBackup)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.CreateBootVolumeBackupErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+
+	if _, ok := state.GetOk("boot_volume_backup_id"); ok {
+		t.Fatalf("should NOT have boot_volume_backup_id")
+	}
+}
+
+func TestStepBootVolumeBackup_cleanupHalted(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	step := new(stepBootVolumeBackup)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	state.Put(multistep.StateHalted, true)
+	step.Cleanup(state)
+
+	if driver.DeleteBootVolumeBackupID != "ocid1.bootvolumebackup..." {
+		t.Fatalf("should've deleted the backup of a halted build")
+	}
+}
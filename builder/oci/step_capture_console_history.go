@@ -0,0 +1,70 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// consoleHistoryTailLines is the number of lines of the console history
+// printed to the UI.
+const consoleHistoryTailLines = 30
+
+// stepCaptureConsoleHistory saves the serial console history of the instance
+// when a later step fails, which usually tells why the instance couldn't be
+// reached. It runs right after stepCreateInstance so that its cleanup runs
+// before the instance is terminated.
+type stepCaptureConsoleHistory struct {
+	// Path is the file the console history is written to.
+	Path string
+}
+
+func (s *stepCaptureConsoleHistory) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	return multistep.ActionContinue
+}
+
+func (s *stepCaptureConsoleHistory) Cleanup(state multistep.StateBag) {
+	_, halted := state.GetOk(multistep.StateHalted)
+	_, cancelled := state.GetOk(multistep.StateCancelled)
+	_, preempted := state.GetOk("instance_preempted")
+	if !halted || cancelled || preempted {
+		return
+	}
+
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.ResourceTimeout)
+	defer cancel()
+
+	ui.Say("Capturing console history of the failed instance...")
+
+	history, err := driver.GetConsoleHistory(ctx, id)
+	if err != nil {
+		ui.Error(fmt.Sprintf("Error capturing console history: %s", err))
+		return
+	}
+
+	if err := os.WriteFile(s.Path, []byte(history), 0600); err != nil {
+		ui.Error(fmt.Sprintf("Error writing console history to %s: %s", s.Path, err))
+		return
+	}
+
+	lines := strings.Split(strings.TrimRight(history, "\n"), "\n")
+	if len(lines) > consoleHistoryTailLines {
+		lines = lines[len(lines)-consoleHistoryTailLines:]
+	}
+	ui.Say(fmt.Sprintf("Console history saved to %s, last lines:", s.Path))
+	ui.Message(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,98 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepCaptureConsoleHistory(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	var history []string
+	for i := 1; i <= 40; i++ {
+		history = append(history, fmt.Sprintf("line %d", i))
+	}
+	driver := state.Get("driver").(*driverMock)
+	driver.GetConsoleHistoryContent = strings.Join(history, "\n")
+
+	path := filepath.Join(t.TempDir(), "oci_test_console.log")
+	step := &stepCaptureConsoleHistory{Path: path}
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	state.Put(multistep.StateHalted, true)
+	step.Cleanup(state)
+
+	if driver.GetConsoleHistoryInstanceID != "ocid1..." {
+		t.Fatalf("bad instance: %s", driver.GetConsoleHistoryInstanceID)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("console history was not written: %s", err)
+	}
+	if string(data) != driver.GetConsoleHistoryContent {
+		t.Fatalf("bad console history: %q", data)
+	}
+}
+
+func TestStepCaptureConsoleHistory_skip(t *testing.T) {
+	for name, keys := range map[string][]string{
+		"succeeded": nil,
+		"cancelled": {multistep.StateHalted, multistep.StateCancelled},
+		"preempted": {multistep.StateHalted, "instance_preempted"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			state := testState()
+			state.Put("instance_id", "ocid1...")
+			for _, key := range keys {
+				state.Put(key, true)
+			}
+
+			path := filepath.Join(t.TempDir(), "oci_test_console.log")
+			step := &stepCaptureConsoleHistory{Path: path}
+			step.Cleanup(state)
+
+			if driver := state.Get("driver").(*driverMock); driver.GetConsoleHistoryInstanceID != "" {
+				t.Fatalf("should not have captured console history")
+			}
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Fatalf("should not have written console history")
+			}
+		})
+	}
+}
+
+func TestStepCaptureConsoleHistory_error(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Put(multistep.StateHalted, true)
+	state.Put("error", errors.New("timeout waiting for SSH"))
+
+	driver := state.Get("driver").(*driverMock)
+	driver.GetConsoleHistoryErr = errors.New("error")
+
+	path := filepath.Join(t.TempDir(), "oci_test_console.log")
+	step := &stepCaptureConsoleHistory{Path: path}
+	step.Cleanup(state)
+
+	// A failed capture must not replace the error of the build.
+	if err := state.Get("error").(error); err.Error() != "timeout waiting for SSH" {
+		t.Fatalf("bad error: %s", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("should not have written console history")
+	}
+}
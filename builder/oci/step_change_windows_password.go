@@ -0,0 +1,176 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/packer-plugin-sdk/communicator"
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/uuid"
+)
+
+// changePasswordScript sets the password of a local user through ADSI rather
+// than "net user", so that it never shows up on a command line, then deletes
+// itself.
+const changePasswordScript = `$ErrorActionPreference = 'Stop'
+try {
+  $user = [ADSI]'WinNT://./%s,user'
+  $user.SetPassword('%s')
+  $user.PasswordExpired = 0
+  $user.SetInfo()
+} finally {
+  Remove-Item -Force -LiteralPath $PSCommandPath
+}
+`
+
+// stepChangeWindowsPassword replaces the password Windows generated for the
+// instance, which has to be changed at first logon, then reconnects with the
+// new password.
+type stepChangeWindowsPassword struct {
+	Debug bool
+	Comm  *communicator.Config
+	Host  func(multistep.StateBag) (string, error)
+
+	// connect reconnects the communicator, it defaults to a StepConnect.
+	connect multistep.Step
+	// connected is the step that reconnected, to be cleaned up.
+	connected multistep.Step
+}
+
+func (s *stepChangeWindowsPassword) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		config = state.Get("config").(*Config)
+		comm   = state.Get("communicator").(packersdk.Communicator)
+		ui     = state.Get("ui").(packersdk.Ui)
+	)
+
+	if !config.ChangeInitialPassword {
+		return multistep.ActionContinue
+	}
+
+	password := config.NewWinRMPassword
+	if password == "" {
+		var err error
+		if password, err = randomWindowsPassword(); err != nil {
+			err = fmt.Errorf("Error generating Windows password: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+	}
+	packersdk.LogSecretFilter.Set(password)
+
+	ui.Say(fmt.Sprintf("Changing the Windows password of %s...", s.Comm.WinRMUser))
+
+	if err := changeWindowsPassword(ctx, comm, ui, s.Comm.WinRMUser, password); err != nil {
+		err = fmt.Errorf("Error changing Windows password: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	s.Comm.WinRMPassword = password
+
+	if s.Debug {
+		ui.Message(fmt.Sprintf(
+			"[DEBUG] (OCI changed credentials): Credentials (since debug is enabled): %s", password))
+	}
+
+	// store so that we can access this later during provisioning
+	state.Put("winrm_password", s.Comm.WinRMPassword)
+
+	// The WinRM communicator authenticates every request with the password
+	// it was created with.
+	ui.Say("Reconnecting with the new password...")
+	connect := s.connect
+	if connect == nil {
+		connect = &communicator.StepConnect{
+			Config: s.Comm,
+			Host:   s.Host,
+		}
+	}
+	s.connected = connect
+	return connect.Run(ctx, state)
+}
+
+func (s *stepChangeWindowsPassword) Cleanup(state multistep.StateBag) {
+	if s.connected != nil {
+		s.connected.Cleanup(state)
+	}
+}
+
+// changeWindowsPassword stages a script setting the password of user on the
+// instance and runs it. The script deletes itself, or is deleted here if it
+// could not run.
+func changeWindowsPassword(ctx context.Context, comm packersdk.Communicator, ui packersdk.Ui, user, password string) error {
+	quote := func(s string) string { return strings.ReplaceAll(s, "'", "''") }
+	script := fmt.Sprintf(changePasswordScript, quote(user), quote(password))
+	path := fmt.Sprintf(`C:/Windows/Temp/packer-password-%s.ps1`, uuid.TimeOrderedUUID())
+
+	if err := comm.Upload(path, strings.NewReader(script), nil); err != nil {
+		return fmt.Errorf("error uploading script: %s", err)
+	}
+
+	cmd := &packersdk.RemoteCmd{
+		Command: fmt.Sprintf(`powershell -NoProfile -NonInteractive -ExecutionPolicy Bypass -File "%s"`, path),
+	}
+	err := cmd.RunWithUi(ctx, comm, ui)
+	if err == nil && cmd.ExitStatus() != 0 {
+		err = fmt.Errorf("script exited with status %d", cmd.ExitStatus())
+	}
+	if err != nil {
+		cleanup := &packersdk.RemoteCmd{
+			Command: fmt.Sprintf(`cmd /c if exist "%s" del /f /q "%s"`, path, path),
+		}
+		if cleanupErr := cleanup.RunWithUi(ctx, comm, ui); cleanupErr != nil {
+			ui.Error(fmt.Sprintf("Error deleting %s, please delete it manually: %s", path, cleanupErr))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// randomWindowsPassword returns a random password meeting the Windows
+// complexity requirements.
+func randomWindowsPassword() (string, error) {
+	sets := []string{
+		"ABCDEFGHJKLMNPQRSTUVWXYZ",
+		"abcdefghijkmnopqrstuvwxyz",
+		"23456789",
+		"!#$*+-.:=?@_",
+	}
+
+	alphabet := strings.Join(sets, "")
+
+	// Every character is taken from the whole alphabet. Passwords missing
+	// one of the sets are drawn again, which rarely happens at this length.
+	for {
+		password := make([]byte, 24)
+		for i := range password {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+			if err != nil {
+				return "", err
+			}
+			password[i] = alphabet[n.Int64()]
+		}
+
+		complete := true
+		for _, set := range sets {
+			if !strings.ContainsAny(string(password), set) {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			return string(password), nil
+		}
+	}
+}
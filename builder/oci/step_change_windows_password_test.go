@@ -0,0 +1,120 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/hashicorp/packer-plugin-sdk/communicator"
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// connectMock records being run in place of a StepConnect.
+type connectMock struct {
+	ran       bool
+	cleanedUp bool
+}
+
+func (s *connectMock) Run(context.Context, multistep.StateBag) multistep.StepAction {
+	s.ran = true
+	return multistep.ActionContinue
+}
+
+func (s *connectMock) Cleanup(multistep.StateBag) {
+	s.cleanedUp = true
+}
+
+func TestStepChangeWindowsPassword(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.ChangeInitialPassword = true
+	config.NewWinRMPassword = "N3w-password"
+
+	comm := &packersdk.MockCommunicator{}
+	state.Put("communicator", comm)
+
+	connect := &connectMock{}
+	commConfig := &communicator.Config{Type: "winrm", WinRM: communicator.WinRM{WinRMUser: "opc", WinRMPassword: "initial-password"}}
+	step := &stepChangeWindowsPassword{Comm: commConfig, connect: connect}
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if !strings.Contains(comm.UploadData, "$user.SetPassword('N3w-password')") {
+		t.Fatalf("bad script: %s", comm.UploadData)
+	}
+	if strings.Contains(comm.StartCmd.Command, "N3w-password") {
+		t.Fatalf("password should not be on the command line: %s", comm.StartCmd.Command)
+	}
+	if !strings.Contains(comm.StartCmd.Command, comm.UploadPath) {
+		t.Fatalf("should run the uploaded script %s: %s", comm.UploadPath, comm.StartCmd.Command)
+	}
+	if commConfig.WinRMPassword != "N3w-password" || state.Get("winrm_password") != "N3w-password" {
+		t.Fatalf("password was not updated: %s", commConfig.WinRMPassword)
+	}
+	if !connect.ran {
+		t.Fatalf("should have reconnected")
+	}
+
+	step.Cleanup(state)
+	if !connect.cleanedUp {
+		t.Fatalf("should have cleaned up the reconnection")
+	}
+}
+
+func TestStepChangeWindowsPassword_failure(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).ChangeInitialPassword = true
+	state.Put("communicator", &packersdk.MockCommunicator{StartExitStatus: 2})
+
+	connect := &connectMock{}
+	commConfig := &communicator.Config{Type: "winrm", WinRM: communicator.WinRM{WinRMUser: "opc", WinRMPassword: "initial-password"}}
+	step := &stepChangeWindowsPassword{Comm: commConfig, connect: connect}
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if commConfig.WinRMPassword != "initial-password" {
+		t.Fatalf("password should not have been updated")
+	}
+	if connect.ran {
+		t.Fatalf("should not have reconnected")
+	}
+}
+
+func TestRandomWindowsPassword(t *testing.T) {
+	password, err := randomWindowsPassword()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(password) != 24 {
+		t.Fatalf("bad length: %d", len(password))
+	}
+	if !strings.ContainsFunc(password, unicode.IsUpper) || !strings.ContainsFunc(password, unicode.IsLower) ||
+		!strings.ContainsFunc(password, unicode.IsDigit) || !strings.ContainsAny(password, "!#$*+-.:=?@_") ||
+		strings.ContainsAny(password, "\"%") {
+		t.Fatalf("password doesn't meet the complexity requirements: %s", password)
+	}
+}
+
+func TestRandomWindowsPassword_NoFixedPattern(t *testing.T) {
+	// With a fixed cycle of character sets, the first character would
+	// always be upper case.
+	for i := 0; i < 100; i++ {
+		password, err := randomWindowsPassword()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !unicode.IsUpper(rune(password[0])) {
+			return
+		}
+	}
+	t.Fatalf("passwords always start with an upper case character")
+}
@@ -0,0 +1,60 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepConnectRunCommand sets up the run_command communicator. It is run by
+// communicator.StepConnect for the "run_command" communicator type.
+type stepConnectRunCommand struct{}
+
+func (s *stepConnectRunCommand) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+	)
+
+	namespace, err := driver.GetNamespace(ctx)
+	if err != nil {
+		err = fmt.Errorf("Error setting up run_command communicator: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	comm := &runCommandCommunicator{
+		driver:     driver,
+		instanceID: id,
+		namespace:  namespace,
+		bucket:     config.RunCommandBucketName,
+		timeout:    config.RunCommandTimeout,
+	}
+
+	// Commands are queued until the agent is up, so the first one tells
+	// when the instance is ready.
+	ui.Say("Waiting for the Run Command plugin to become available...")
+	if err := comm.run(ctx, "true"); err != nil {
+		err = fmt.Errorf("Error waiting for the Run Command plugin: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	ui.Say("Connected through Run Command.")
+	state.Put("communicator", comm)
+
+	return multistep.ActionContinue
+}
+
+func (s *stepConnectRunCommand) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
@@ -0,0 +1,55 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepConnectRunCommand(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).RunCommandBucketName = "bucket"
+
+	step := new(stepConnectRunCommand)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	comm, ok := state.Get("communicator").(*runCommandCommunicator)
+	if !ok {
+		t.Fatalf("should have run_command communicator")
+	}
+	if comm.instanceID != "ocid1..." || comm.namespace != "namespace" || comm.bucket != "bucket" {
+		t.Fatalf("bad communicator: %#v", comm)
+	}
+	if len(driver.RunInstanceCommandScripts) != 1 {
+		t.Fatalf("should have waited for the Run Command plugin")
+	}
+}
+
+func TestStepConnectRunCommand_notReady(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	step := new(stepConnectRunCommand)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.RunInstanceCommandExitCode = 127
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("communicator"); ok {
+		t.Fatalf("should not have communicator")
+	}
+}
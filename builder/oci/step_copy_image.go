@@ -0,0 +1,155 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/uuid"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+type stepCopyImage struct {
+	Regions []string
+}
+
+func (s *stepCopyImage) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	if len(s.Regions) == 0 {
+		return multistep.ActionContinue
+	}
+
+	rawImage, ok := state.GetOk("image")
+	if !ok {
+		ui.Say("Skipping image copy since no image was created...")
+		return multistep.ActionContinue
+	}
+	image := rawImage.(core.Image)
+
+	// Builds sharing an image name must not overwrite each other's export.
+	objectName := fmt.Sprintf("%s-%s.oci", config.ImageName, uuid.TimeOrderedUUID())
+
+	namespace, err := driver.GetNamespace(ctx)
+	if err != nil {
+		err = fmt.Errorf("Error exporting image: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	ui.Say(fmt.Sprintf("Exporting image to bucket '%s'...", config.CopyImageBucketName))
+
+	_, err = driver.ExportImage(ctx, *image.Id, namespace, config.CopyImageBucketName, objectName, "OCI")
+	if err != nil {
+		err = fmt.Errorf("Error exporting image: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	state.Put("image_export_namespace", namespace)
+	state.Put("image_export_object", objectName)
+
+	// The request only has to outlive the imports of this build.
+	uri, err := driver.CreateObjectReadURI(ctx, namespace, config.CopyImageBucketName, objectName, time.Now().Add(24*time.Hour))
+	if err != nil {
+		err = fmt.Errorf("Error exporting image: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	// Nothing else should be able to read the export once the imports are
+	// done.
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), config.ResourceTimeout)
+		defer cancel()
+		if err := driver.DeleteObjectURIs(ctx, namespace, config.CopyImageBucketName, objectName); err != nil {
+			ui.Error(fmt.Sprintf("Error deleting pre-authenticated request for exported image %s. Please delete it manually: %s", objectName, err))
+		}
+	}()
+
+	copies := make(map[string]string)
+	state.Put("image_copies", copies)
+
+	for _, region := range s.Regions {
+		ui.Say(fmt.Sprintf("Copying image to region '%s'...", region))
+
+		imageCopy, err := driver.ImportImage(ctx, region, core.ImageSourceViaObjectStorageUriDetails{
+			SourceUri: &uri,
+		})
+		if err != nil {
+			err = fmt.Errorf("Error importing image into region %s: %s", region, err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+
+		copies[region] = *imageCopy.Id
+
+		if err := driver.WaitForImageImport(ctx, region, *imageCopy.Id); err != nil {
+			err = fmt.Errorf("Error waiting for image import into region %s to finish: %s", region, err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+
+		// The schema holds the launch mode, launch options and platform
+		// features instances launched from the copy default to.
+		if err := driver.UpdateImageCapabilitySchemaInRegion(ctx, region, *imageCopy.Id); err != nil {
+			err = fmt.Errorf("Error updating image schema in region %s: %s", region, err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+
+		ui.Say(fmt.Sprintf("Copied image to region '%s' (%s).", region, *imageCopy.Id))
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *stepCopyImage) Cleanup(state multistep.StateBag) {
+	driver := state.Get("driver").(Driver)
+	ui := state.Get("ui").(packersdk.Ui)
+	config := state.Get("config").(*Config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.ResourceTimeout)
+	defer cancel()
+
+	if objectName, ok := state.GetOk("image_export_object"); ok {
+		namespace := state.Get("image_export_namespace").(string)
+		ui.Say("Deleting exported image...")
+		if err := driver.DeleteObject(ctx, namespace, config.CopyImageBucketName, objectName.(string)); err != nil {
+			ui.Error(fmt.Sprintf("Error deleting exported image %s. Please delete it manually: %s", objectName, err))
+		}
+	}
+
+	_, cancelled := state.GetOk(multistep.StateCancelled)
+	_, halted := state.GetOk(multistep.StateHalted)
+	if !cancelled && !halted {
+		return
+	}
+
+	copies, ok := state.GetOk("image_copies")
+	if !ok {
+		return
+	}
+
+	for region, id := range copies.(map[string]string) {
+		ui.Say(fmt.Sprintf("Deleting image copy in region '%s' (%s)...", region, id))
+		if err := driver.DeleteImageInRegion(ctx, region, id); err != nil {
+			ui.Error(fmt.Sprintf("Error deleting image copy %s. Please delete it manually: %s", id, err))
+		}
+	}
+}
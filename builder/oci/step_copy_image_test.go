@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestStepCopyImage(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image.oc1.iad.aaa")})
+
+	step := &stepCopyImage{Regions: []string{"us-phoenix-1", "eu-frankfurt-1"}}
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.ExportImageID != "ocid1.image.oc1.iad.aaa" {
+		t.Fatalf("should've exported image (%s)", driver.ExportImageID)
+	}
+
+	if !reflect.DeepEqual(driver.ImportImageRegions, step.Regions) {
+		t.Fatalf("should've imported image into %v, got %v", step.Regions, driver.ImportImageRegions)
+	}
+	if !reflect.DeepEqual(driver.UpdateSchemaRegions, step.Regions) {
+		t.Fatalf("should've updated the image schema in %v, got %v", step.Regions, driver.UpdateSchemaRegions)
+	}
+
+	copies, ok := state.GetOk("image_copies")
+	if !ok {
+		t.Fatalf("should have image_copies")
+	}
+	if len(copies.(map[string]string)) != len(step.Regions) {
+		t.Fatalf("should have %d image copies, got %v", len(step.Regions), copies)
+	}
+
+	if driver.DeleteObjectURIsName != driver.ExportImageObject {
+		t.Fatalf("should've deleted the pre-authenticated request of the exported image (%s != %s)",
+			driver.DeleteObjectURIsName, driver.ExportImageObject)
+	}
+
+	config := state.Get("config").(*Config)
+	if !strings.HasPrefix(driver.ExportImageObject, config.ImageName+"-") || driver.ExportImageObject == config.ImageName+".oci" {
+		t.Fatalf("exported image should have a unique name: %s", driver.ExportImageObject)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteObjectName != driver.ExportImageObject {
+		t.Fatalf("should've deleted exported image (%s != %s)",
+			driver.DeleteObjectName, driver.ExportImageObject)
+	}
+
+	if driver.DeleteImageInRegionIDs != nil {
+		t.Fatalf("should NOT have deleted image copies: %v", driver.DeleteImageInRegionIDs)
+	}
+}
+
+func TestStepCopyImage_NoRegions(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image.oc1.iad.aaa")})
+
+	step := new(stepCopyImage)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.ExportImageID != "" {
+		t.Fatalf("should NOT have exported image")
+	}
+}
+
+func TestStepCopyImage_ImportImageErr(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: stringPtr("ocid1.image.oc1.iad.aaa")})
+
+	step := &stepCopyImage{Regions: []string{"us-phoenix-1"}}
+
+	driver := state.Get("driver").(*driverMock)
+	driver.WaitForImageImportErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+
+	if driver.DeleteObjectURIsName != driver.ExportImageObject {
+		t.Fatalf("should've deleted the pre-authenticated request of the exported image")
+	}
+
+	state.Put(multistep.StateHalted, true)
+	step.Cleanup(state)
+
+	if driver.DeleteImageInRegionIDs["us-phoenix-1"] != "ocid1.image.oc1.us-phoenix-1" {
+		t.Fatalf("should've deleted image copy, got %v", driver.DeleteImageInRegionIDs)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/tmp"
+)
+
+// stepCreateBastionSession creates a session on the configured OCI Bastion
+// and points the SSH communicator's bastion settings at it.
+type stepCreateBastionSession struct {
+	privateKeyFile string
+}
+
+func (s *stepCreateBastionSession) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+		id     = state.Get("instance_id").(string)
+		ip     = state.Get("instance_ip").(string)
+	)
+
+	if config.BastionID == "" {
+		return multistep.ActionContinue
+	}
+
+	ui.Say(fmt.Sprintf("Creating %s bastion session...", config.BastionSessionType))
+
+	session, err := driver.CreateBastionSession(ctx, id, ip, config.Comm.Port(), string(config.Comm.SSHPublicKey))
+	if session.ID != "" {
+		state.Put("bastion_session_id", session.ID)
+	}
+	if err != nil {
+		err = fmt.Errorf("Error creating bastion session: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	// The bastion authenticates with the same key as the instance. The SSH
+	// communicator only reads bastion keys from disk, so a generated key
+	// has to be written out for the duration of the build.
+	keyFile := config.Comm.SSHPrivateKeyFile
+	if keyFile == "" {
+		f, err := tmp.File("oci-bastion-key")
+		if err != nil {
+			err = fmt.Errorf("Error creating temporary bastion key file: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+		s.privateKeyFile = f.Name()
+
+		_, err = f.Write(config.Comm.SSHPrivateKey)
+		f.Close()
+		if err != nil {
+			err = fmt.Errorf("Error writing temporary bastion key file: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+		keyFile = s.privateKeyFile
+	}
+
+	config.Comm.SSHBastionHost = session.Host
+	config.Comm.SSHBastionPort = 22
+	config.Comm.SSHBastionUsername = session.Username
+	config.Comm.SSHBastionPrivateKeyFile = keyFile
+
+	ui.Say(fmt.Sprintf("Created bastion session: %s.", session.ID))
+
+	return multistep.ActionContinue
+}
+
+func (s *stepCreateBastionSession) Cleanup(state multistep.StateBag) {
+	if s.privateKeyFile != "" {
+		os.Remove(s.privateKeyFile)
+	}
+
+	id, ok := state.GetOk("bastion_session_id")
+	if !ok {
+		return
+	}
+
+	driver := state.Get("driver").(Driver)
+	ui := state.Get("ui").(packersdk.Ui)
+	config := state.Get("config").(*Config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.ResourceTimeout)
+	defer cancel()
+
+	ui.Say(fmt.Sprintf("Deleting bastion session: %s...", id))
+
+	if err := driver.DeleteBastionSession(ctx, id.(string)); err != nil {
+		ui.Error(fmt.Sprintf("Error deleting bastion session %s. Please delete it manually: %s", id, err))
+	}
+}
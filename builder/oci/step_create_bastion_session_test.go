@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepCreateBastionSession(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Put("instance_ip", "10.0.0.2")
+
+	config := state.Get("config").(*Config)
+	config.BastionID = "ocid1.bastion..."
+	config.BastionSessionType = "MANAGED_SSH"
+	config.Comm.SSHPrivateKey = []byte("private key")
+
+	step := new(stepCreateBastionSession)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.CreateBastionSessionInstanceID != "ocid1..." || driver.CreateBastionSessionIP != "10.0.0.2" || driver.CreateBastionSessionPort != 22 {
+		t.Fatalf("bad bastion session target: %s %s:%d",
+			driver.CreateBastionSessionInstanceID, driver.CreateBastionSessionIP, driver.CreateBastionSessionPort)
+	}
+
+	if config.Comm.SSHBastionHost != "host.bastion.us-ashburn-1.oci.oraclecloud.com" {
+		t.Fatalf("bad ssh_bastion_host: %s", config.Comm.SSHBastionHost)
+	}
+	if config.Comm.SSHBastionUsername != "ocid1.bastionsession..." {
+		t.Fatalf("bad ssh_bastion_username: %s", config.Comm.SSHBastionUsername)
+	}
+
+	keyFile := config.Comm.SSHBastionPrivateKeyFile
+	key, err := os.ReadFile(keyFile)
+	if err != nil {
+		t.Fatalf("should've written bastion key file: %s", err)
+	}
+	if string(key) != "private key" {
+		t.Fatalf("bad bastion key: %s", key)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteBastionSessionID != "ocid1.bastionsession..." {
+		t.Fatalf("should've deleted bastion session")
+	}
+	if _, err := os.Stat(keyFile); !os.IsNotExist(err) {
+		t.Fatalf("should've removed bastion key file")
+	}
+}
+
+func TestStepCreateBastionSession_noBastion(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Put("instance_ip", "10.0.0.2")
+
+	step := new(stepCreateBastionSession)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.CreateBastionSessionInstanceID != "" {
+		t.Fatalf("should not have created bastion session")
+	}
+
+	if config := state.Get("config").(*Config); config.Comm.SSHBastionHost != "" {
+		t.Fatalf("should not have set ssh_bastion_host")
+	}
+}
+
+func TestStepCreateBastionSession_CreateBastionSessionErr(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Put("instance_ip", "10.0.0.2")
+	state.Get("config").(*Config).BastionID = "ocid1.bastion..."
+
+	step := new(stepCreateBastionSession)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.CreateBastionSessionErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
@@ -5,14 +5,89 @@ package oci
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/packer-plugin-sdk/multistep"
 	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/packerbuilderdata"
 )
 
 type stepCreateInstance struct{}
 
+// placement is a combination of availability domain, fault domain and shape
+// to launch the instance with.
+type placement struct {
+	availabilityDomain string
+	faultDomain        string
+	shape              string
+	shapeConfig        FlexShapeConfig
+}
+
+func (p placement) String() string {
+	s := fmt.Sprintf("shape %s in %s", p.shape, p.availabilityDomain)
+	if p.faultDomain != "" {
+		s += " " + p.faultDomain
+	}
+	return s
+}
+
+// launchAvailabilityDomains returns the configured availability domains the
+// instance can be launched in, and those it can't. An AD-specific subnet
+// only allows the availability domain it lives in.
+func launchAvailabilityDomains(ctx context.Context, driver Driver, config *Config) ([]string, []string, error) {
+	ads := append([]string{config.AvailabilityDomain}, config.AvailabilityDomains...)
+	if len(ads) == 1 || config.CreateVnicDetails.SubnetId == nil {
+		return ads, nil, nil
+	}
+
+	subnetID := *config.CreateVnicDetails.SubnetId
+	subnet, err := driver.GetSubnet(ctx, subnetID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error looking up subnet %s: %s", subnetID, err)
+	}
+	if subnet.AvailabilityDomain == nil {
+		return ads, nil, nil
+	}
+
+	if !slices.Contains(ads, *subnet.AvailabilityDomain) {
+		return nil, nil, fmt.Errorf("Subnet %s is specific to availability domain %q, which is not one of the configured availability domains",
+			subnetID, *subnet.AvailabilityDomain)
+	}
+
+	var skipped []string
+	for _, ad := range ads {
+		if ad != *subnet.AvailabilityDomain {
+			skipped = append(skipped, ad)
+		}
+	}
+	return []string{*subnet.AvailabilityDomain}, skipped, nil
+}
+
+// launchPlacements returns the placements to try in order: every fault
+// domain of every availability domain for the configured shape first, then
+// the same for each fallback shape.
+func launchPlacements(config *Config, ads []string) []placement {
+	fds := config.FaultDomains
+	if len(fds) == 0 {
+		// Let OCI pick the fault domain.
+		fds = []string{""}
+	}
+	shapes := append([]FallbackShape{{Shape: config.Shape, ShapeConfig: config.ShapeConfig}}, config.FallbackShapes...)
+
+	var ps []placement
+	for _, shape := range shapes {
+		for _, ad := range ads {
+			for _, fd := range fds {
+				ps = append(ps, placement{availabilityDomain: ad, faultDomain: fd, shape: shape.Shape, shapeConfig: shape.ShapeConfig})
+			}
+		}
+	}
+	return ps
+}
+
 func (s *stepCreateInstance) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
 	var (
 		driver = state.Get("driver").(Driver)
@@ -22,7 +97,34 @@ func (s *stepCreateInstance) Run(ctx context.Context, state multistep.StateBag)
 
 	ui.Say("Creating instance...")
 
-	instanceID, err := driver.CreateInstance(ctx, string(config.Comm.SSHPublicKey))
+	ads, skipped, err := launchAvailabilityDomains(ctx, driver, config)
+	if err != nil {
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+	if len(skipped) > 0 {
+		ui.Say(fmt.Sprintf("Subnet is specific to %s, skipping availability domains %s...", ads[0], strings.Join(skipped, ", ")))
+	}
+
+	// Walk through the configured placements until one has capacity. The
+	// driver launches the instance with whatever the config points at.
+	var (
+		instanceID string
+		launched   placement
+	)
+	placements := launchPlacements(config, ads)
+	for i, p := range placements {
+		launched = p
+		config.AvailabilityDomain, config.faultDomain = p.availabilityDomain, p.faultDomain
+		config.Shape, config.ShapeConfig = p.shape, p.shapeConfig
+
+		instanceID, err = driver.CreateInstance(ctx, string(config.Comm.SSHPublicKey))
+		if !errors.Is(err, errOutOfCapacity) || i == len(placements)-1 {
+			break
+		}
+		ui.Say(fmt.Sprintf("Out of capacity for %s, trying %s...", p, placements[i+1]))
+	}
 	if err != nil {
 		err = fmt.Errorf("Problem creating instance: %s", err)
 		ui.Error(err.Error())
@@ -32,12 +134,28 @@ func (s *stepCreateInstance) Run(ctx context.Context, state multistep.StateBag)
 
 	state.Put("instance_id", instanceID)
 
+	generatedData := &packerbuilderdata.GeneratedData{State: state}
+	generatedData.Put("InstanceOCID", instanceID)
+	generatedData.Put("CapacityReservationOCID", config.CapacityReservationID)
+	generatedData.Put("DedicatedVmHostOCID", config.DedicatedVmHostID)
+	generatedData.Put("AvailabilityDomain", config.AvailabilityDomain)
+	generatedData.Put("FaultDomain", config.faultDomain)
+	generatedData.Put("Shape", config.Shape)
+
 	ui.Say(fmt.Sprintf("Created instance (%s).", instanceID))
+	if len(placements) > 1 {
+		ui.Say(fmt.Sprintf("Instance was launched with %s.", launched))
+	}
 
 	ui.Say("Waiting for instance to enter 'RUNNING' state...")
 
-	if err = driver.WaitForInstanceState(ctx, instanceID, []string{"STARTING", "PROVISIONING"}, "RUNNING"); err != nil {
-		err = fmt.Errorf("Error waiting for instance to start: %s", err)
+	if err = driver.WaitForInstanceState(ctx, instanceID, []string{"STARTING", "PROVISIONING"}, "RUNNING", config.InstanceLaunchTimeout); err != nil {
+		if errors.Is(err, errInstanceTerminated) && config.PreemptibleInstanceConfig != nil {
+			state.Put("instance_preempted", true)
+			err = fmt.Errorf("Instance was reclaimed by OCI before it was running: %s", err)
+		} else {
+			err = fmt.Errorf("Error waiting for instance to start: %s", err)
+		}
 		ui.Error(err.Error())
 		state.Put("error", err)
 		return multistep.ActionHalt
@@ -51,6 +169,7 @@ func (s *stepCreateInstance) Run(ctx context.Context, state multistep.StateBag)
 func (s *stepCreateInstance) Cleanup(state multistep.StateBag) {
 	driver := state.Get("driver").(Driver)
 	ui := state.Get("ui").(packersdk.Ui)
+	config := state.Get("config").(*Config)
 
 	idRaw, ok := state.GetOk("instance_id")
 	if !ok {
@@ -58,16 +177,44 @@ func (s *stepCreateInstance) Cleanup(state multistep.StateBag) {
 	}
 	id := idRaw.(string)
 
-	ui.Say(fmt.Sprintf("Terminating instance (%s)...", id))
+	ctx, cancel := context.WithTimeout(context.Background(), config.InstanceTerminateTimeout)
+	defer cancel()
+
+	// A preemptible instance reclaimed during provisioning only shows up as
+	// a failing step, so check whether that is what happened.
+	_, preempted := state.GetOk("instance_preempted")
+	if _, halted := state.GetOk(multistep.StateHalted); halted && !preempted && config.PreemptibleInstanceConfig != nil {
+		instanceState, err := driver.GetInstanceState(ctx, id)
+		if err == nil && (instanceState == "TERMINATING" || instanceState == "TERMINATED") {
+			ui.Error(fmt.Sprintf("Instance (%s) was reclaimed by OCI during the build.", id))
+			state.Put("instance_preempted", true)
+			preempted = true
+		}
+	}
 
-	if err := driver.TerminateInstance(context.TODO(), id); err != nil {
-		err = fmt.Errorf("Error terminating instance. Please terminate manually: %s", err)
-		ui.Error(err.Error())
-		state.Put("error", err)
-		return
+	// Every reclaimed attempt leaves a preserved boot volume behind, which
+	// the builder doesn't use.
+	if preempted && config.PreemptibleInstanceConfig != nil && config.PreemptibleInstanceConfig.PreserveBootVolume {
+		if bootVolumeID, err := driver.GetInstanceBootVolumeID(ctx, id); err != nil {
+			ui.Error(fmt.Sprintf("Error looking up the preserved boot volume of instance %s. Please delete it manually: %s", id, err))
+		} else {
+			ui.Say(fmt.Sprintf("The boot volume of the reclaimed instance was preserved (%s). Please delete it when it is no longer needed.", bootVolumeID))
+			state.Put("preserved_boot_volume_id", bootVolumeID)
+		}
+	}
+
+	if !preempted {
+		ui.Say(fmt.Sprintf("Terminating instance (%s)...", id))
+
+		if err := driver.TerminateInstance(ctx, id); err != nil {
+			err = fmt.Errorf("Error terminating instance. Please terminate manually: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return
+		}
 	}
 
-	err := driver.WaitForInstanceState(context.TODO(), id, []string{"TERMINATING"}, "TERMINATED")
+	err := driver.WaitForInstanceState(ctx, id, []string{"TERMINATING"}, "TERMINATED", config.InstanceTerminateTimeout)
 	if err != nil {
 		err = fmt.Errorf("Error terminating instance. Please terminate manually: %s", err)
 		ui.Error(err.Error())
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/packer-plugin-sdk/multistep"
 )
@@ -38,6 +39,170 @@ func TestStepCreateInstance(t *testing.T) {
 	}
 }
 
+func TestStepCreateInstance_Timeouts(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+	config := state.Get("config").(*Config)
+	config.InstanceLaunchTimeout = 10 * time.Minute
+	config.InstanceTerminateTimeout = 20 * time.Minute
+
+	step := new(stepCreateInstance)
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if driver.WaitForInstanceStateTimeout != config.InstanceLaunchTimeout {
+		t.Errorf("launch waited for %s, expected %s", driver.WaitForInstanceStateTimeout, config.InstanceLaunchTimeout)
+	}
+
+	step.Cleanup(state)
+
+	if driver.WaitForInstanceStateTimeout != config.InstanceTerminateTimeout {
+		t.Errorf("termination waited for %s, expected %s", driver.WaitForInstanceStateTimeout, config.InstanceTerminateTimeout)
+	}
+}
+
+func TestStepCreateInstance_GeneratedData(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+	state.Get("config").(*Config).DedicatedVmHostID = "ocid1.dedicatedvmhost..."
+
+	step := new(stepCreateInstance)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	generatedData := state.Get("generated_data").(map[string]interface{})
+	if generatedData["DedicatedVmHostOCID"] != "ocid1.dedicatedvmhost..." {
+		t.Fatalf("bad DedicatedVmHostOCID: %v", generatedData["DedicatedVmHostOCID"])
+	}
+	if generatedData["CapacityReservationOCID"] != "" {
+		t.Fatalf("bad CapacityReservationOCID: %v", generatedData["CapacityReservationOCID"])
+	}
+}
+
+func TestStepCreateInstance_CapacityFallback(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+
+	config := state.Get("config").(*Config)
+	config.AvailabilityDomain = "aaaa:PHX-AD-1"
+	config.AvailabilityDomains = []string{"aaaa:PHX-AD-2"}
+	config.FaultDomains = []string{"FAULT-DOMAIN-1", "FAULT-DOMAIN-2"}
+	config.Shape = "VM.Standard.E4.Flex"
+	config.FallbackShapes = []FallbackShape{{Shape: "VM.Standard3.Flex"}}
+
+	driver := state.Get("driver").(*driverMock)
+	driver.CreateInstanceCapacityFailures = 5
+
+	step := new(stepCreateInstance)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	expected := []string{
+		"shape VM.Standard.E4.Flex in aaaa:PHX-AD-1 FAULT-DOMAIN-1",
+		"shape VM.Standard.E4.Flex in aaaa:PHX-AD-1 FAULT-DOMAIN-2",
+		"shape VM.Standard.E4.Flex in aaaa:PHX-AD-2 FAULT-DOMAIN-1",
+		"shape VM.Standard.E4.Flex in aaaa:PHX-AD-2 FAULT-DOMAIN-2",
+		"shape VM.Standard3.Flex in aaaa:PHX-AD-1 FAULT-DOMAIN-1",
+		"shape VM.Standard3.Flex in aaaa:PHX-AD-1 FAULT-DOMAIN-2",
+	}
+	if len(driver.CreateInstancePlacements) != len(expected) {
+		t.Fatalf("bad number of launches: %d instead of %d", len(driver.CreateInstancePlacements), len(expected))
+	}
+	for i, p := range driver.CreateInstancePlacements {
+		if p.String() != expected[i] {
+			t.Fatalf("bad launch %d: %s instead of %s", i, p, expected[i])
+		}
+	}
+
+	generatedData := state.Get("generated_data").(map[string]interface{})
+	if generatedData["Shape"] != "VM.Standard3.Flex" || generatedData["AvailabilityDomain"] != "aaaa:PHX-AD-1" || generatedData["FaultDomain"] != "FAULT-DOMAIN-2" {
+		t.Fatalf("bad generated data: %v", generatedData)
+	}
+}
+
+func TestStepCreateInstance_AvailabilityDomainSpecificSubnet(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+
+	config := state.Get("config").(*Config)
+	config.AvailabilityDomain = "aaaa:PHX-AD-1"
+	config.AvailabilityDomains = []string{"aaaa:PHX-AD-2", "aaaa:PHX-AD-3"}
+	config.FallbackShapes = []FallbackShape{{Shape: "VM.Standard3.Flex"}}
+
+	driver := state.Get("driver").(*driverMock)
+	driver.GetSubnetAvailabilityDomain = "aaaa:PHX-AD-2"
+	driver.CreateInstanceCapacityFailures = 1
+
+	step := new(stepCreateInstance)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	expected := []string{
+		"shape VM.Standard1.1 in aaaa:PHX-AD-2",
+		"shape VM.Standard3.Flex in aaaa:PHX-AD-2",
+	}
+	if len(driver.CreateInstancePlacements) != len(expected) {
+		t.Fatalf("bad number of launches: %d instead of %d", len(driver.CreateInstancePlacements), len(expected))
+	}
+	for i, p := range driver.CreateInstancePlacements {
+		if p.String() != expected[i] {
+			t.Fatalf("bad launch %d: %s instead of %s", i, p, expected[i])
+		}
+	}
+}
+
+func TestStepCreateInstance_AvailabilityDomainSpecificSubnetMismatch(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+	state.Get("config").(*Config).AvailabilityDomains = []string{"aaaa:PHX-AD-2"}
+
+	driver := state.Get("driver").(*driverMock)
+	driver.GetSubnetAvailabilityDomain = "aaaa:PHX-AD-3"
+
+	step := new(stepCreateInstance)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if len(driver.CreateInstancePlacements) != 0 {
+		t.Fatalf("should not have launched an instance")
+	}
+}
+
+func TestStepCreateInstance_OutOfCapacity(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+	state.Get("config").(*Config).AvailabilityDomains = []string{"aaaa:PHX-AD-2"}
+
+	driver := state.Get("driver").(*driverMock)
+	driver.CreateInstanceCapacityFailures = 2
+
+	step := new(stepCreateInstance)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if len(driver.CreateInstancePlacements) != 2 {
+		t.Fatalf("bad number of launches: %d instead of 2", len(driver.CreateInstancePlacements))
+	}
+	if _, ok := state.GetOk("instance_id"); ok {
+		t.Fatalf("should not have instance")
+	}
+}
+
 func TestStepCreateInstance_InstanceOptions(t *testing.T) {
 	runTest := func(t *testing.T, value *bool, expected *bool) {
 		state := testState() // testState already calls Prepare on a base config
@@ -188,3 +353,84 @@ func TestStepCreateInstanceCleanup_WaitForInstanceStateErr(t *testing.T) {
 		t.Fatalf("should have error")
 	}
 }
+
+func TestStepCreateInstance_Preempted(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+	state.Get("config").(*Config).PreemptibleInstanceConfig = &PreemptibleInstanceConfig{}
+
+	step := new(stepCreateInstance)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.WaitForInstanceStateErr = errInstanceTerminated
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("instance_preempted"); !ok {
+		t.Fatalf("should have instance_preempted")
+	}
+
+	driver.WaitForInstanceStateErr = nil
+	state.Put(multistep.StateHalted, true)
+	step.Cleanup(state)
+
+	if driver.TerminateInstanceID != "" {
+		t.Fatalf("should not have terminated a reclaimed instance")
+	}
+
+	if _, ok := state.GetOk("preserved_boot_volume_id"); ok {
+		t.Fatalf("should not report a boot volume that was not preserved")
+	}
+}
+
+func TestStepCreateInstanceCleanup_PreservedBootVolume(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+	state.Get("config").(*Config).PreemptibleInstanceConfig = &PreemptibleInstanceConfig{PreserveBootVolume: true}
+
+	step := new(stepCreateInstance)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.WaitForInstanceStateErr = errInstanceTerminated
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	driver.WaitForInstanceStateErr = nil
+	state.Put(multistep.StateHalted, true)
+	step.Cleanup(state)
+
+	if id := state.Get("preserved_boot_volume_id"); id != "ocid1.bootvolume..." {
+		t.Fatalf("should have reported the preserved boot volume, got %v", id)
+	}
+}
+
+func TestStepCreateInstanceCleanup_PreemptedDuringBuild(t *testing.T) {
+	state := testState()
+	state.Put("publicKey", "key")
+	state.Get("config").(*Config).PreemptibleInstanceConfig = &PreemptibleInstanceConfig{}
+
+	step := new(stepCreateInstance)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	// A later step failed because the instance went away.
+	driver.GetInstanceStateState = "TERMINATED"
+	state.Put(multistep.StateHalted, true)
+	step.Cleanup(state)
+
+	if _, ok := state.GetOk("instance_preempted"); !ok {
+		t.Fatalf("should have instance_preempted")
+	}
+
+	if driver.TerminateInstanceID != "" {
+		t.Fatalf("should not have terminated a reclaimed instance")
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepCreateNetwork creates a temporary VCN and public subnet for the build
+// instance when no subnet was specified.
+type stepCreateNetwork struct{}
+
+func (s *stepCreateNetwork) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	if config.CreateVnicDetails.SubnetId != nil {
+		return multistep.ActionContinue
+	}
+
+	ui.Say("Creating temporary networking...")
+
+	network, err := driver.CreateTemporaryNetwork(ctx, config.Comm.Port())
+	// Whatever got created has to be cleaned up, even on failure.
+	state.Put("temporary_network", network)
+	if err != nil {
+		err = fmt.Errorf("Error creating temporary networking: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	config.CreateVnicDetails.SubnetId = &network.SubnetID
+
+	ui.Say(fmt.Sprintf("Created temporary VCN %s with subnet %s.", network.VcnID, network.SubnetID))
+
+	return multistep.ActionContinue
+}
+
+func (s *stepCreateNetwork) Cleanup(state multistep.StateBag) {
+	rawNetwork, ok := state.GetOk("temporary_network")
+	if !ok {
+		return
+	}
+	network := rawNetwork.(TemporaryNetwork)
+	if network.VcnID == "" {
+		return
+	}
+
+	driver := state.Get("driver").(Driver)
+	ui := state.Get("ui").(packersdk.Ui)
+	config := state.Get("config").(*Config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.ResourceTimeout)
+	defer cancel()
+
+	ui.Say(fmt.Sprintf("Deleting temporary networking (VCN %s)...", network.VcnID))
+
+	if err := driver.DeleteTemporaryNetwork(ctx, network); err != nil {
+		ui.Error(fmt.Sprintf("Error deleting temporary networking. Please delete VCN %s manually: %s", network.VcnID, err))
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepCreateNetwork(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.CreateVnicDetails.SubnetId = nil
+
+	step := new(stepCreateNetwork)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.CreateTemporaryNetworkPort != config.Comm.Port() {
+		t.Fatalf("should've opened port %d, got %d", config.Comm.Port(), driver.CreateTemporaryNetworkPort)
+	}
+
+	if config.CreateVnicDetails.SubnetId == nil || *config.CreateVnicDetails.SubnetId != "ocid1.subnet..." {
+		t.Fatalf("should've set the subnet to the temporary one, got %v", config.CreateVnicDetails.SubnetId)
+	}
+
+	step.Cleanup(state)
+
+	if driver.DeleteTemporaryNetworkVcnID != "ocid1.vcn..." {
+		t.Fatalf("should've deleted temporary networking")
+	}
+}
+
+func TestStepCreateNetwork_withSubnet(t *testing.T) {
+	state := testState()
+
+	step := new(stepCreateNetwork)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("temporary_network"); ok {
+		t.Fatalf("should not have created temporary networking")
+	}
+
+	if driver.CreateTemporaryNetworkPort != 0 {
+		t.Fatalf("should not have created temporary networking")
+	}
+}
+
+func TestStepCreateNetwork_CreateTemporaryNetworkErr(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).CreateVnicDetails.SubnetId = nil
+
+	step := new(stepCreateNetwork)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.CreateTemporaryNetworkErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+
+	// Partially created resources are still cleaned up.
+	driver.CreateTemporaryNetworkErr = nil
+	step.Cleanup(state)
+
+	if driver.DeleteTemporaryNetworkVcnID != "ocid1.vcn..." {
+		t.Fatalf("should've deleted partially created temporary networking")
+	}
+}
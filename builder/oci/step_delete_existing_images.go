@@ -0,0 +1,136 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// stepFindExistingImages looks up the images already named like the image to
+// create when force_delete_existing_image is set, before anything is
+// launched, so that stepDeleteExistingImages can delete them once the new
+// image is available. An image_name using generated data is only known once
+// the instance is running, so the lookup is left to stepDeleteExistingImages.
+type stepFindExistingImages struct {
+	SkipCreateImage bool
+}
+
+func (s *stepFindExistingImages) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	if s.SkipCreateImage || !config.ForceDeleteExistingImage {
+		return multistep.ActionContinue
+	}
+	if strings.Contains(config.ImageName, "{{") {
+		ui.Say("Existing images will be looked up once image_name is rendered.")
+		return multistep.ActionContinue
+	}
+
+	ui.Say(fmt.Sprintf("Looking up existing images named %s...", config.ImageName))
+
+	existing, err := findExistingImages(ctx, driver, config, "")
+	if err != nil {
+		err = fmt.Errorf("Error looking up existing images: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	if len(existing) == 0 {
+		ui.Message("No existing images found.")
+	} else {
+		ui.Message(fmt.Sprintf("Found %d existing image(s), they will be deleted once the build succeeded.", len(existing)))
+	}
+	state.Put("existing_image_ids", existing)
+
+	return multistep.ActionContinue
+}
+
+func (s *stepFindExistingImages) Cleanup(state multistep.StateBag) {
+	// Nothing to do
+}
+
+// stepDeleteExistingImages deletes the images found by
+// stepFindExistingImages once the new image, its copies and backups are
+// available, so a failed build leaves them alone. When they were not
+// looked up before the build, they are looked up now.
+type stepDeleteExistingImages struct{}
+
+func (s *stepDeleteExistingImages) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	if !config.ForceDeleteExistingImage {
+		return multistep.ActionContinue
+	}
+	rawImage, ok := state.GetOk("image")
+	if !ok {
+		return multistep.ActionContinue
+	}
+
+	var existing []string
+	if rawExisting, ok := state.GetOk("existing_image_ids"); ok {
+		existing = rawExisting.([]string)
+	} else {
+		var err error
+		existing, err = findExistingImages(ctx, driver, config, *rawImage.(core.Image).Id)
+		if err != nil {
+			err = fmt.Errorf("Error looking up existing images: %s", err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+	}
+	if len(existing) == 0 {
+		return multistep.ActionContinue
+	}
+
+	ui.Say(fmt.Sprintf("Deleting existing images named %s...", config.ImageName))
+
+	for _, id := range existing {
+		if err := driver.DeleteImage(ctx, id); err != nil {
+			err = fmt.Errorf("Error deleting existing image %s: %s", id, err)
+			ui.Error(err.Error())
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+		ui.Message(fmt.Sprintf("Deleted image %s", id))
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *stepDeleteExistingImages) Cleanup(state multistep.StateBag) {
+	// Nothing to do
+}
+
+// findExistingImages returns the OCIDs of the images of the image compartment
+// named like the image to create, other than newImageID.
+func findExistingImages(ctx context.Context, driver Driver, config *Config, newImageID string) ([]string, error) {
+	images, err := driver.ListCustomImages(ctx, config.ImageCompartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := []string{}
+	for _, image := range images {
+		if *image.Id != newImageID && imageDisplayName(image) == config.ImageName {
+			existing = append(existing, *image.Id)
+		}
+	}
+	return existing, nil
+}
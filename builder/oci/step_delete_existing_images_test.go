@@ -0,0 +1,141 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestStepDeleteExistingImages(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.ForceDeleteExistingImage = true
+	state.Put("image", core.Image{Id: common.String("ocid1.image.new")})
+
+	driver := state.Get("driver").(*driverMock)
+	driver.ListCustomImagesImages = []core.Image{
+		{Id: common.String("ocid1.image.1"), DisplayName: common.String(config.ImageName)},
+		{Id: common.String("ocid1.image.2"), DisplayName: common.String("other")},
+		{Id: common.String("ocid1.image.new"), DisplayName: common.String(config.ImageName)},
+		{Id: common.String("ocid1.image.3"), DisplayName: common.String(config.ImageName)},
+	}
+
+	step := new(stepDeleteExistingImages)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if expected := []string{"ocid1.image.1", "ocid1.image.3"}; !reflect.DeepEqual(driver.DeleteImageIDs, expected) {
+		t.Errorf("deleted %v, expected %v", driver.DeleteImageIDs, expected)
+	}
+}
+
+func TestStepFindExistingImages(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.ForceDeleteExistingImage = true
+
+	driver := state.Get("driver").(*driverMock)
+	driver.ListCustomImagesImages = []core.Image{
+		{Id: common.String("ocid1.image.1"), DisplayName: common.String(config.ImageName)},
+		{Id: common.String("ocid1.image.2"), DisplayName: common.String("other")},
+	}
+
+	find := new(stepFindExistingImages)
+	defer find.Cleanup(state)
+
+	if action := find.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	// Images named like the new one since the lookup are left alone.
+	driver.ListCustomImagesImages = append(driver.ListCustomImagesImages,
+		core.Image{Id: common.String("ocid1.image.3"), DisplayName: common.String(config.ImageName)})
+	state.Put("image", core.Image{Id: common.String("ocid1.image.new")})
+
+	step := new(stepDeleteExistingImages)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if expected := []string{"ocid1.image.1"}; !reflect.DeepEqual(driver.DeleteImageIDs, expected) {
+		t.Errorf("deleted %v, expected %v", driver.DeleteImageIDs, expected)
+	}
+}
+
+func TestStepFindExistingImages_TemplatedName(t *testing.T) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.ForceDeleteExistingImage = true
+	config.ImageName = "{{ .SourceImageName }}-hardened"
+	driver := state.Get("driver").(*driverMock)
+	driver.ListCustomImagesErr = errors.New("should not list images")
+
+	step := new(stepFindExistingImages)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("existing_image_ids"); ok {
+		t.Fatalf("should leave the lookup to stepDeleteExistingImages")
+	}
+}
+
+func TestStepDeleteExistingImages_Disabled(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: common.String("ocid1.image.new")})
+	driver := state.Get("driver").(*driverMock)
+	driver.ListCustomImagesErr = errors.New("should not list images")
+
+	step := new(stepDeleteExistingImages)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+}
+
+func TestStepDeleteExistingImages_NoImage(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).ForceDeleteExistingImage = true
+	driver := state.Get("driver").(*driverMock)
+	driver.ListCustomImagesErr = errors.New("should not list images")
+
+	step := new(stepDeleteExistingImages)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+}
+
+func TestStepDeleteExistingImages_ListErr(t *testing.T) {
+	state := testState()
+	state.Get("config").(*Config).ForceDeleteExistingImage = true
+	state.Put("image", core.Image{Id: common.String("ocid1.image.new")})
+	driver := state.Get("driver").(*driverMock)
+	driver.ListCustomImagesErr = errors.New("error")
+
+	step := new(stepDeleteExistingImages)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
@@ -21,7 +21,7 @@ type stepGetDefaultCredentials struct {
 
 func (s *stepGetDefaultCredentials) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
 	var (
-		driver = state.Get("driver").(*driverOCI)
+		driver = state.Get("driver").(Driver)
 		ui     = state.Get("ui").(packersdk.Ui)
 		id     = state.Get("instance_id").(string)
 	)
@@ -0,0 +1,76 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/communicator"
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepGetDefaultCredentials(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	comm := &communicator.Config{Type: "winrm"}
+	step := &stepGetDefaultCredentials{Comm: comm}
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if driver.GetInstanceInitialCredentialsID != "ocid1..." {
+		t.Fatalf("bad instance: %s", driver.GetInstanceInitialCredentialsID)
+	}
+	if comm.WinRMUser != "opc" || comm.WinRMPassword != "initial-password" {
+		t.Fatalf("bad credentials: %s/%s", comm.WinRMUser, comm.WinRMPassword)
+	}
+	if state.Get("winrm_password") != "initial-password" {
+		t.Fatalf("bad winrm_password: %v", state.Get("winrm_password"))
+	}
+}
+
+func TestStepGetDefaultCredentials_skip(t *testing.T) {
+	for name, comm := range map[string]*communicator.Config{
+		"ssh":      {Type: "ssh"},
+		"password": {Type: "winrm", WinRM: communicator.WinRM{WinRMPassword: "password"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			state := testState()
+			state.Put("instance_id", "ocid1...")
+
+			step := &stepGetDefaultCredentials{Comm: comm}
+			driver := state.Get("driver").(*driverMock)
+
+			if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+				t.Fatalf("bad action: %#v", action)
+			}
+			if driver.GetInstanceInitialCredentialsID != "" {
+				t.Fatalf("should not have fetched credentials")
+			}
+		})
+	}
+}
+
+func TestStepGetDefaultCredentials_error(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	step := &stepGetDefaultCredentials{Comm: &communicator.Config{Type: "winrm"}}
+	driver := state.Get("driver").(*driverMock)
+	driver.GetInstanceInitialCredentialsErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
@@ -13,6 +13,8 @@ import (
 
 type stepImage struct {
 	SkipCreateImage bool
+
+	imageID string
 }
 
 func (s *stepImage) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
@@ -36,6 +38,7 @@ func (s *stepImage) Run(ctx context.Context, state multistep.StateBag) multistep
 		state.Put("error", err)
 		return multistep.ActionHalt
 	}
+	s.imageID = *image.Id
 
 	err = driver.WaitForImageCreation(ctx, *image.Id)
 	if err != nil {
@@ -63,6 +66,28 @@ func (s *stepImage) Run(ctx context.Context, state multistep.StateBag) multistep
 	return multistep.ActionContinue
 }
 
+// Cleanup deletes the image when the build failed or was cancelled, as no
+// artifact is returned for it.
 func (s *stepImage) Cleanup(state multistep.StateBag) {
-	// Nothing to do
+	if s.imageID == "" {
+		return
+	}
+
+	_, cancelled := state.GetOk(multistep.StateCancelled)
+	_, halted := state.GetOk(multistep.StateHalted)
+	if !cancelled && !halted {
+		return
+	}
+
+	driver := state.Get("driver").(Driver)
+	ui := state.Get("ui").(packersdk.Ui)
+	config := state.Get("config").(*Config)
+
+	ui.Say(fmt.Sprintf("Deleting image %s...", s.imageID))
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.ResourceTimeout)
+	defer cancel()
+	if err := driver.DeleteImage(ctx, s.imageID); err != nil {
+		ui.Error(fmt.Sprintf("Error deleting image %s. Please delete it manually: %s", s.imageID, err))
+	}
 }
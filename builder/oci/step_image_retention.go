@@ -0,0 +1,176 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// imageDeprecatedTag is the freeform tag set on images deprecated by the
+// image retention policy, its value is the time of the deprecation.
+const imageDeprecatedTag = "deprecated"
+
+// stepImageRetention applies the image retention policy once the image was
+// created: the newest images of the same lineage are kept, the older ones
+// are deleted or deprecated, in the build region and in every region the
+// image was copied to. Failures are reported but don't fail the build,
+// as the image is already available.
+type stepImageRetention struct {
+	SkipCreateImage bool
+}
+
+func (s *stepImageRetention) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	retention := config.ImageRetention
+	if s.SkipCreateImage || retention == nil {
+		return multistep.ActionContinue
+	}
+	rawImage, ok := state.GetOk("image")
+	if !ok {
+		return multistep.ActionContinue
+	}
+	image := rawImage.(core.Image)
+
+	ui.Say("Applying image retention policy...")
+
+	s.apply(ctx, driver, ui, config, "", *image.Id)
+
+	// Copies made with copy_to_regions form a lineage of their own in each
+	// region.
+	if rawCopies, ok := state.GetOk("image_copies"); ok {
+		copies := rawCopies.(map[string]string)
+		regions := slices.Sorted(maps.Keys(copies))
+		for _, region := range regions {
+			s.apply(ctx, driver, ui, config, region, copies[region])
+		}
+	}
+
+	return multistep.ActionContinue
+}
+
+// apply applies the retention policy to the images of a region, or of the
+// build region when region is empty.
+func (s *stepImageRetention) apply(ctx context.Context, driver Driver, ui packersdk.Ui, config *Config, region string, newImageID string) {
+	retention := config.ImageRetention
+
+	where := ""
+	if region != "" {
+		where = fmt.Sprintf(" in region '%s'", region)
+	}
+
+	var (
+		images []core.Image
+		err    error
+	)
+	if region == "" {
+		images, err = driver.ListCustomImages(ctx, config.ImageCompartmentID)
+	} else {
+		images, err = driver.ListCustomImagesInRegion(ctx, region, config.ImageCompartmentID)
+	}
+	if err != nil {
+		ui.Error(fmt.Sprintf("Error listing images%s for image retention: %s", where, err))
+		return
+	}
+
+	expired := expiredImages(retention, newImageID, images)
+	if len(expired) == 0 {
+		ui.Message(fmt.Sprintf("No images to remove%s.", where))
+		return
+	}
+
+	for _, old := range expired {
+		name := fmt.Sprintf("%s (%s)%s", imageDisplayName(old), *old.Id, where)
+
+		if retention.DryRun {
+			ui.Message(fmt.Sprintf("Would %s image %s", retention.Action, name))
+			continue
+		}
+
+		switch {
+		case retention.Action == "deprecate":
+			tags := maps.Clone(old.FreeformTags)
+			if tags == nil {
+				tags = make(map[string]string)
+			}
+			tags[imageDeprecatedTag] = time.Now().UTC().Format(time.RFC3339)
+			if region == "" {
+				err = driver.UpdateImageFreeformTags(ctx, *old.Id, tags)
+			} else {
+				err = driver.UpdateImageFreeformTagsInRegion(ctx, region, *old.Id, tags)
+			}
+		case region == "":
+			err = driver.DeleteImage(ctx, *old.Id)
+		default:
+			err = driver.DeleteImageInRegion(ctx, region, *old.Id)
+		}
+		if err != nil {
+			ui.Error(fmt.Sprintf("Error applying image retention to image %s: %s", name, err))
+			continue
+		}
+
+		ui.Message(fmt.Sprintf("Applied %s to image %s", retention.Action, name))
+	}
+}
+
+func (s *stepImageRetention) Cleanup(state multistep.StateBag) {
+	// Nothing to do
+}
+
+// expiredImages returns the images of the lineage selected by the retention
+// policy that fall out of the images to keep. The image just created always
+// counts as the newest one.
+func expiredImages(retention *ImageRetention, newImageID string, images []core.Image) []core.Image {
+	var lineage []core.Image
+	for _, image := range images {
+		if image.Id == nil || *image.Id == newImageID {
+			continue
+		}
+		if !retention.Matches(imageDisplayName(image), image.FreeformTags) {
+			continue
+		}
+		if _, deprecated := image.FreeformTags[imageDeprecatedTag]; deprecated && retention.Action == "deprecate" {
+			continue
+		}
+		lineage = append(lineage, image)
+	}
+
+	sort.SliceStable(lineage, func(i, j int) bool {
+		return imageTimeCreated(lineage[i]).After(imageTimeCreated(lineage[j]))
+	})
+
+	// The new image takes one of the places to keep.
+	keep := retention.Keep - 1
+	if keep >= len(lineage) {
+		return nil
+	}
+	return lineage[keep:]
+}
+
+func imageDisplayName(image core.Image) string {
+	if image.DisplayName == nil {
+		return ""
+	}
+	return *image.DisplayName
+}
+
+func imageTimeCreated(image core.Image) time.Time {
+	if image.TimeCreated == nil {
+		return time.Time{}
+	}
+	return image.TimeCreated.Time
+}
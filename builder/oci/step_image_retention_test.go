@@ -0,0 +1,190 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func testRetentionImage(id, name string, age time.Duration, tags map[string]string) core.Image {
+	return core.Image{
+		Id:           common.String(id),
+		DisplayName:  common.String(name),
+		TimeCreated:  &common.SDKTime{Time: time.Now().Add(-age)},
+		FreeformTags: tags,
+	}
+}
+
+func testImageRetentionState(t *testing.T, retention *ImageRetention) (multistep.StateBag, *driverMock) {
+	if errs := retention.Prepare(); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	state := testState()
+	state.Get("config").(*Config).ImageRetention = retention
+	state.Put("image", core.Image{Id: common.String("ocid1.image.new")})
+
+	driver := state.Get("driver").(*driverMock)
+	driver.ListCustomImagesImages = []core.Image{
+		testRetentionImage("ocid1.image.new", "packer-5", 0, nil),
+		testRetentionImage("ocid1.image.2", "packer-2", 3*time.Hour, nil),
+		testRetentionImage("ocid1.image.4", "packer-4", 1*time.Hour, nil),
+		testRetentionImage("ocid1.image.1", "packer-1", 4*time.Hour, nil),
+		testRetentionImage("ocid1.image.3", "packer-3", 2*time.Hour, nil),
+		testRetentionImage("ocid1.image.other", "other", 5*time.Hour, nil),
+	}
+
+	return state, driver
+}
+
+func TestStepImageRetention_Delete(t *testing.T) {
+	state, driver := testImageRetentionState(t, &ImageRetention{
+		DisplayNamePattern: "^packer-",
+		Keep:               3,
+	})
+
+	step := new(stepImageRetention)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	expected := []string{"ocid1.image.2", "ocid1.image.1"}
+	if !reflect.DeepEqual(driver.DeleteImageIDs, expected) {
+		t.Errorf("deleted %v, expected %v", driver.DeleteImageIDs, expected)
+	}
+	if driver.ListCustomImagesCompartmentID != state.Get("config").(*Config).ImageCompartmentID {
+		t.Errorf("listed images in wrong compartment %q", driver.ListCustomImagesCompartmentID)
+	}
+}
+
+func TestStepImageRetention_Deprecate(t *testing.T) {
+	state, driver := testImageRetentionState(t, &ImageRetention{
+		Tags:   map[string]string{"lineage": "web"},
+		Keep:   1,
+		Action: "deprecate",
+	})
+	driver.ListCustomImagesImages = []core.Image{
+		testRetentionImage("ocid1.image.1", "web-1", 2*time.Hour, map[string]string{"lineage": "web"}),
+		testRetentionImage("ocid1.image.2", "web-2", 1*time.Hour, map[string]string{"lineage": "web", imageDeprecatedTag: "2025-01-01T00:00:00Z"}),
+		testRetentionImage("ocid1.image.3", "db-1", 1*time.Hour, map[string]string{"lineage": "db"}),
+	}
+
+	step := new(stepImageRetention)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if !reflect.DeepEqual(driver.UpdateImageFreeformTagsIDs, []string{"ocid1.image.1"}) {
+		t.Fatalf("deprecated %v, expected only ocid1.image.1", driver.UpdateImageFreeformTagsIDs)
+	}
+	tags := driver.UpdateImageFreeformTagsTags["ocid1.image.1"]
+	if tags["lineage"] != "web" {
+		t.Errorf("existing tags should be kept, got %v", tags)
+	}
+	if _, err := time.Parse(time.RFC3339, tags[imageDeprecatedTag]); err != nil {
+		t.Errorf("bad %s tag: %s", imageDeprecatedTag, err)
+	}
+	if len(driver.DeleteImageIDs) != 0 {
+		t.Errorf("should not delete images, deleted %v", driver.DeleteImageIDs)
+	}
+}
+
+func TestStepImageRetention_DryRun(t *testing.T) {
+	state, driver := testImageRetentionState(t, &ImageRetention{
+		DisplayNamePattern: "^packer-",
+		Keep:               1,
+		DryRun:             true,
+	})
+
+	step := new(stepImageRetention)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if len(driver.DeleteImageIDs) != 0 || len(driver.UpdateImageFreeformTagsIDs) != 0 {
+		t.Errorf("dry run should not change images")
+	}
+}
+
+func TestStepImageRetention_Skip(t *testing.T) {
+	state := testState()
+	state.Put("image", core.Image{Id: common.String("ocid1.image.new")})
+	driver := state.Get("driver").(*driverMock)
+	driver.ListCustomImagesErr = errors.New("should not list images")
+
+	step := new(stepImageRetention)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+}
+
+func TestStepImageRetention_ErrorsDoNotHalt(t *testing.T) {
+	state, driver := testImageRetentionState(t, &ImageRetention{
+		DisplayNamePattern: "^packer-",
+		Keep:               1,
+	})
+	driver.DeleteImageErr = errors.New("error")
+
+	step := new(stepImageRetention)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); ok {
+		t.Fatalf("should NOT have error")
+	}
+}
+
+func TestStepImageRetention_Copies(t *testing.T) {
+	state, driver := testImageRetentionState(t, &ImageRetention{
+		DisplayNamePattern: "^packer-",
+		Keep:               2,
+	})
+	state.Put("image_copies", map[string]string{
+		"us-phoenix-1":   "ocid1.image.phx.new",
+		"eu-frankfurt-1": "ocid1.image.fra.new",
+	})
+	driver.ListCustomImagesInRegionImages = map[string][]core.Image{
+		"us-phoenix-1": {
+			testRetentionImage("ocid1.image.phx.new", "packer-3", 0, nil),
+			testRetentionImage("ocid1.image.phx.1", "packer-1", 2*time.Hour, nil),
+			testRetentionImage("ocid1.image.phx.2", "packer-2", 1*time.Hour, nil),
+		},
+		"eu-frankfurt-1": {
+			testRetentionImage("ocid1.image.fra.new", "packer-3", 0, nil),
+		},
+	}
+
+	step := new(stepImageRetention)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	expected := map[string]string{"us-phoenix-1": "ocid1.image.phx.1"}
+	if !reflect.DeepEqual(driver.DeleteImageInRegionIDs, expected) {
+		t.Errorf("deleted copies %v, expected %v", driver.DeleteImageInRegionIDs, expected)
+	}
+	if len(driver.DeleteImageIDs) != 3 {
+		t.Errorf("should still apply the policy in the build region, deleted %v", driver.DeleteImageIDs)
+	}
+}
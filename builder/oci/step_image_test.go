@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/oracle/oci-go-sdk/v65/core"
 )
 
 func TestStepImage(t *testing.T) {
@@ -72,3 +73,27 @@ func TestStepImage_WaitForImageCreationErr(t *testing.T) {
 		t.Fatalf("should not have image")
 	}
 }
+
+func TestStepImageCleanup_Halted(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	step := new(stepImage)
+
+	driver := state.Get("driver").(*driverMock)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	step.Cleanup(state)
+	if driver.DeleteImageID != "" {
+		t.Fatalf("should NOT have deleted the image of a successful build")
+	}
+
+	state.Put(multistep.StateHalted, true)
+	step.Cleanup(state)
+	if driver.DeleteImageID != *state.Get("image").(core.Image).Id {
+		t.Fatalf("should've deleted the image, got %q", driver.DeleteImageID)
+	}
+}
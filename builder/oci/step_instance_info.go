@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/packer-plugin-sdk/multistep"
 	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/packerbuilderdata"
 )
 
 type stepInstanceInfo struct{}
@@ -17,10 +18,16 @@ func (s *stepInstanceInfo) Run(ctx context.Context, state multistep.StateBag) mu
 	var (
 		driver = state.Get("driver").(Driver)
 		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
 		id     = state.Get("instance_id").(string)
 	)
 
-	ip, err := driver.GetInstanceIP(ctx, id)
+	// The run_command communicator goes through the Oracle Cloud Agent and
+	// never connects to the instance, so it doesn't need a public IP.
+	privateIP, publicIP, err := driver.GetInstanceIPs(ctx, id)
+	if err == nil && publicIP == "" && !config.UsePrivateIP && config.Comm.Type != "run_command" {
+		err = fmt.Errorf("error getting VNIC Public Ip for: %s", id)
+	}
 	if err != nil {
 		err = fmt.Errorf("Error getting instance's IP: %s", err)
 		ui.Error(err.Error())
@@ -28,8 +35,16 @@ func (s *stepInstanceInfo) Run(ctx context.Context, state multistep.StateBag) mu
 		return multistep.ActionHalt
 	}
 
+	ip := publicIP
+	if config.UsePrivateIP {
+		ip = privateIP
+	}
 	state.Put("instance_ip", ip)
 
+	generatedData := &packerbuilderdata.GeneratedData{State: state}
+	generatedData.Put("PrivateIP", privateIP)
+	generatedData.Put("PublicIP", publicIP)
+
 	ui.Say(fmt.Sprintf("Instance has IP: %s.", ip))
 
 	return multistep.ActionContinue
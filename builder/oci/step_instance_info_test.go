@@ -64,7 +64,63 @@ func TestInstanceInfoPrivateIP(t *testing.T) {
 	}
 }
 
-func TestInstanceInfo_GetInstanceIPErr(t *testing.T) {
+func TestInstanceInfo_GeneratedData(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	step := new(stepInstanceInfo)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	generatedData := state.Get("generated_data").(map[string]interface{})
+	if generatedData["PrivateIP"] != "private_ip" || generatedData["PublicIP"] != "ip" {
+		t.Fatalf("bad generated data: %v", generatedData)
+	}
+}
+
+func TestInstanceInfo_NoPublicIP(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+
+	step := new(stepInstanceInfo)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.GetInstanceIPsNoPublicIP = true
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
+
+func TestInstanceInfo_NoPublicIPRunCommand(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1...")
+	state.Get("config").(*Config).Comm.Type = "run_command"
+
+	step := new(stepInstanceInfo)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*driverMock)
+	driver.GetInstanceIPsNoPublicIP = true
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); ok {
+		t.Fatalf("should NOT have error")
+	}
+}
+
+func TestInstanceInfo_GetInstanceIPsErr(t *testing.T) {
 	state := testState()
 	state.Put("instance_id", "ocid1...")
 
@@ -72,7 +128,7 @@ func TestInstanceInfo_GetInstanceIPErr(t *testing.T) {
 	defer step.Cleanup(state)
 
 	driver := state.Get("driver").(*driverMock)
-	driver.GetInstanceIPErr = errors.New("error")
+	driver.GetInstanceIPsErr = errors.New("error")
 
 	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
 		t.Fatalf("bad action: %#v", action)
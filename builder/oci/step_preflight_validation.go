@@ -0,0 +1,205 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// stepPreflightValidation checks the configuration against OCI before any
+// resource is created, so that a typo in an OCID or an unavailable shape
+// fails the build right away. Every problem found is reported at once.
+type stepPreflightValidation struct{}
+
+func (s *stepPreflightValidation) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	if !config.PreflightValidation {
+		return multistep.ActionContinue
+	}
+
+	ui.Say("Validating configuration against OCI...")
+
+	var errs *packersdk.MultiError
+
+	compartments := []string{config.CompartmentID}
+	if config.ImageCompartmentID != config.CompartmentID {
+		compartments = append(compartments, config.ImageCompartmentID)
+	}
+	for _, id := range compartments {
+		if _, err := driver.GetCompartment(ctx, id); err != nil {
+			errs = packersdk.MultiErrorAppend(errs, fmt.Errorf("compartment %s: %s", id, err))
+		}
+	}
+
+	// Shapes are only looked up in the availability domains the instance
+	// may be launched in, and only in those that exist, so a typo is
+	// reported once. A subnet problem is reported below.
+	availabilityDomains := append([]string{config.AvailabilityDomain}, config.AvailabilityDomains...)
+	launchDomains, _, err := launchAvailabilityDomains(ctx, driver, config)
+	if err != nil {
+		launchDomains = availabilityDomains
+	}
+	shapeDomains := launchDomains
+	if ads, err := driver.ListAvailabilityDomains(ctx, config.CompartmentID); err != nil {
+		errs = packersdk.MultiErrorAppend(errs, fmt.Errorf("error listing availability domains: %s", err))
+	} else {
+		var names []string
+		for _, ad := range ads {
+			if ad.Name != nil {
+				names = append(names, *ad.Name)
+			}
+		}
+		shapeDomains = nil
+		for _, ad := range availabilityDomains {
+			if !slices.Contains(names, ad) {
+				errs = packersdk.MultiErrorAppend(errs, fmt.Errorf("availability domain %q does not exist, available: %v", ad, names))
+				continue
+			}
+			if slices.Contains(launchDomains, ad) {
+				shapeDomains = append(shapeDomains, ad)
+			}
+		}
+	}
+
+	if subnetID := config.CreateVnicDetails.SubnetId; subnetID != nil {
+		subnet, err := driver.GetSubnet(ctx, *subnetID)
+		if err != nil {
+			errs = packersdk.MultiErrorAppend(errs, fmt.Errorf("subnet %s: %s", *subnetID, err))
+		} else if subnet.AvailabilityDomain != nil && !slices.Contains(availabilityDomains, *subnet.AvailabilityDomain) {
+			// The other availability domains are skipped at launch.
+			errs = packersdk.MultiErrorAppend(errs, fmt.Errorf(
+				"subnet %s is specific to availability domain %q, which is not one of the configured availability domains",
+				*subnetID, *subnet.AvailabilityDomain))
+		}
+	}
+
+	for _, id := range config.CreateVnicDetails.NsgIds {
+		if _, err := driver.GetNetworkSecurityGroup(ctx, id); err != nil {
+			errs = packersdk.MultiErrorAppend(errs, fmt.Errorf("network security group %s: %s", id, err))
+		}
+	}
+
+	// Every shape the instance may be launched with has to be available,
+	// and compatible with the base image, in every availability domain it
+	// may be launched in.
+	shapes := append([]FallbackShape{{Shape: config.Shape, ShapeConfig: config.ShapeConfig}}, config.FallbackShapes...)
+	validated := map[int]bool{}
+	for _, ad := range shapeDomains {
+		available, err := driver.ListShapes(ctx, ad, "")
+		if err != nil {
+			errs = packersdk.MultiErrorAppend(errs, fmt.Errorf("error listing shapes in availability domain %q: %s", ad, err))
+			continue
+		}
+		for i, candidate := range shapes {
+			shape, ok := findShape(available, candidate.Shape)
+			if !ok {
+				errs = packersdk.MultiErrorAppend(errs, fmt.Errorf(
+					"shape %q is not available in availability domain %q", candidate.Shape, ad))
+				continue
+			}
+			if validated[i] {
+				continue
+			}
+			validated[i] = true
+			field := "shape_config"
+			if i > 0 {
+				field = fmt.Sprintf("fallback_shapes[%d].shape_config", i-1)
+			}
+			for _, err := range validateShapeConfig(shape, candidate.ShapeConfig, field) {
+				errs = packersdk.MultiErrorAppend(errs, err)
+			}
+		}
+	}
+
+	if image, err := driver.GetSourceImage(ctx); err != nil {
+		errs = packersdk.MultiErrorAppend(errs, fmt.Errorf("error finding base image: %s", err))
+	} else {
+		incompatible := map[string]bool{}
+		for _, ad := range shapeDomains {
+			compatible, err := driver.ListShapes(ctx, ad, *image.Id)
+			if err != nil {
+				errs = packersdk.MultiErrorAppend(errs, fmt.Errorf(
+					"error listing shapes compatible with base image %s in availability domain %q: %s", *image.Id, ad, err))
+				continue
+			}
+			for _, candidate := range shapes {
+				if _, ok := findShape(compatible, candidate.Shape); !ok && !incompatible[candidate.Shape] {
+					incompatible[candidate.Shape] = true
+					errs = packersdk.MultiErrorAppend(errs, fmt.Errorf(
+						"base image %s (%s) is not compatible with shape %q", imageDisplayName(image), *image.Id, candidate.Shape))
+				}
+			}
+		}
+	}
+
+	if errs != nil && len(errs.Errors) > 0 {
+		err := fmt.Errorf("Preflight validation failed: %s", errs)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	ui.Message("Configuration is valid.")
+	return multistep.ActionContinue
+}
+
+func (s *stepPreflightValidation) Cleanup(state multistep.StateBag) {
+	// Nothing to do
+}
+
+func findShape(shapes []core.Shape, name string) (core.Shape, bool) {
+	for _, shape := range shapes {
+		if shape.Shape != nil && *shape.Shape == name {
+			return shape, true
+		}
+	}
+	return core.Shape{}, false
+}
+
+// validateShapeConfig checks the OCPUs and memory of a flexible shape against
+// the ranges the shape supports. field is the path of shapeConfig in the
+// configuration, used in the errors.
+func validateShapeConfig(shape core.Shape, shapeConfig FlexShapeConfig, field string) []error {
+	var errs []error
+
+	if options := shape.OcpuOptions; options != nil && shapeConfig.Ocpus != nil {
+		ocpus := *shapeConfig.Ocpus
+		if (options.Min != nil && ocpus < *options.Min) || (options.Max != nil && ocpus > *options.Max) {
+			errs = append(errs, fmt.Errorf("'%s.ocpus' %g is out of range for shape %q (%s)",
+				field, ocpus, *shape.Shape, formatRange(options.Min, options.Max)))
+		}
+	}
+
+	if options := shape.MemoryOptions; options != nil && shapeConfig.MemoryInGBs != nil {
+		memory := *shapeConfig.MemoryInGBs
+		if (options.MinInGBs != nil && memory < *options.MinInGBs) || (options.MaxInGBs != nil && memory > *options.MaxInGBs) {
+			errs = append(errs, fmt.Errorf("'%s.memory_in_gbs' %g is out of range for shape %q (%s)",
+				field, memory, *shape.Shape, formatRange(options.MinInGBs, options.MaxInGBs)))
+		}
+	}
+
+	return errs
+}
+
+func formatRange(min, max *float32) string {
+	switch {
+	case min != nil && max != nil:
+		return fmt.Sprintf("%g-%g", *min, *max)
+	case min != nil:
+		return fmt.Sprintf("at least %g", *min)
+	default:
+		return fmt.Sprintf("at most %g", *max)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func testPreflightState() (multistep.StateBag, *driverMock) {
+	state := testState()
+	config := state.Get("config").(*Config)
+	config.PreflightValidation = true
+	config.CreateVnicDetails.NsgIds = []string{"ocid1.networksecuritygroup.oc1.iad.aaa"}
+	return state, state.Get("driver").(*driverMock)
+}
+
+func TestStepPreflightValidation(t *testing.T) {
+	state, _ := testPreflightState()
+
+	step := new(stepPreflightValidation)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); ok {
+		t.Fatalf("should NOT have error")
+	}
+}
+
+func TestStepPreflightValidation_Disabled(t *testing.T) {
+	state := testState()
+	driver := state.Get("driver").(*driverMock)
+	driver.GetCompartmentErr = errors.New("should not look up compartment")
+
+	step := new(stepPreflightValidation)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+}
+
+func TestStepPreflightValidation_ReportsAllErrors(t *testing.T) {
+	state, driver := testPreflightState()
+	config := state.Get("config").(*Config)
+	config.AvailabilityDomains = []string{"aaaa:US-ASHBURN-AD-2", "aaaa:US-ASHBURN-AD-9"}
+	driver.GetCompartmentErr = errors.New("compartment not found")
+	driver.GetSubnetAvailabilityDomain = "aaaa:US-ASHBURN-AD-3"
+	driver.GetNetworkSecurityGroupErrs = map[string]error{
+		"ocid1.networksecuritygroup.oc1.iad.aaa": errors.New("nsg not found"),
+	}
+	driver.ListShapesImageShapes = []core.Shape{}
+
+	step := new(stepPreflightValidation)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	err := state.Get("error").(error).Error()
+	for _, expected := range []string{
+		"compartment not found",
+		`availability domain "aaaa:US-ASHBURN-AD-9" does not exist`,
+		`is specific to availability domain "aaaa:US-ASHBURN-AD-3"`,
+		"nsg not found",
+		`is not compatible with shape "VM.Standard1.1"`,
+	} {
+		if !strings.Contains(err, expected) {
+			t.Errorf("expected error to contain %q, got: %s", expected, err)
+		}
+	}
+}
+
+func TestStepPreflightValidation_Shape(t *testing.T) {
+	state, driver := testPreflightState()
+	driver.ListShapesShapes = []core.Shape{{Shape: common.String("VM.Standard.E4.Flex")}}
+
+	step := new(stepPreflightValidation)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if err := state.Get("error").(error).Error(); !strings.Contains(err, `shape "VM.Standard1.1" is not available`) {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestValidateShapeConfig(t *testing.T) {
+	shape := core.Shape{
+		Shape: common.String("VM.Standard.E4.Flex"),
+		OcpuOptions: &core.ShapeOcpuOptions{
+			Min: common.Float32(1),
+			Max: common.Float32(64),
+		},
+		MemoryOptions: &core.ShapeMemoryOptions{
+			MinInGBs: common.Float32(1),
+			MaxInGBs: common.Float32(1024),
+		},
+	}
+
+	if errs := validateShapeConfig(shape, FlexShapeConfig{Ocpus: common.Float32(2), MemoryInGBs: common.Float32(32)}, "shape_config"); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if errs := validateShapeConfig(shape, FlexShapeConfig{}, "shape_config"); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	errs := validateShapeConfig(shape, FlexShapeConfig{Ocpus: common.Float32(128), MemoryInGBs: common.Float32(2048)}, "fallback_shapes[1].shape_config")
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got: %v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "'fallback_shapes[1].shape_config.ocpus'") {
+		t.Errorf("error should point at the configured field: %s", errs[0])
+	}
+}
+
+func TestStepPreflightValidation_FallbackAvailabilityDomains(t *testing.T) {
+	state, driver := testPreflightState()
+	config := state.Get("config").(*Config)
+	config.AvailabilityDomains = []string{"aaaa:US-ASHBURN-AD-2"}
+	driver.ListShapesDomainShapes = map[string][]core.Shape{
+		"aaaa:US-ASHBURN-AD-2": {{Shape: common.String("VM.Standard.E4.Flex")}},
+	}
+
+	step := new(stepPreflightValidation)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	err := state.Get("error").(error).Error()
+	if !strings.Contains(err, `shape "VM.Standard1.1" is not available in availability domain "aaaa:US-ASHBURN-AD-2"`) {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if strings.Contains(err, `"aaaa:US-ASHBURN-AD-1"`) {
+		t.Errorf("should not report the primary availability domain: %s", err)
+	}
+}
+
+func TestStepPreflightValidation_FallbackShapes(t *testing.T) {
+	state, driver := testPreflightState()
+	config := state.Get("config").(*Config)
+	config.FallbackShapes = []FallbackShape{
+		{Shape: "VM.Standard.E4.Flex", ShapeConfig: FlexShapeConfig{Ocpus: common.Float32(128)}},
+		{Shape: "VM.Standard.A1.Flex"},
+		{Shape: "VM.Standard3.Flex"},
+	}
+	driver.ListShapesShapes = []core.Shape{
+		{Shape: common.String("VM.Standard1.1")},
+		{
+			Shape:       common.String("VM.Standard.E4.Flex"),
+			OcpuOptions: &core.ShapeOcpuOptions{Min: common.Float32(1), Max: common.Float32(64)},
+		},
+		{Shape: common.String("VM.Standard.A1.Flex")},
+	}
+	driver.ListShapesImageShapes = []core.Shape{
+		{Shape: common.String("VM.Standard1.1")},
+		{Shape: common.String("VM.Standard.E4.Flex")},
+	}
+
+	step := new(stepPreflightValidation)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	err := state.Get("error").(error).Error()
+	for _, expected := range []string{
+		`'fallback_shapes[0].shape_config.ocpus' 128 is out of range for shape "VM.Standard.E4.Flex"`,
+		`is not compatible with shape "VM.Standard.A1.Flex"`,
+		`shape "VM.Standard3.Flex" is not available`,
+	} {
+		if !strings.Contains(err, expected) {
+			t.Errorf("expected error to contain %q, got: %s", expected, err)
+		}
+	}
+}
+
+func TestStepPreflightValidation_AvailabilityDomainSpecificSubnet(t *testing.T) {
+	state, driver := testPreflightState()
+	config := state.Get("config").(*Config)
+	config.AvailabilityDomains = []string{"aaaa:US-ASHBURN-AD-2"}
+	driver.GetSubnetAvailabilityDomain = "aaaa:US-ASHBURN-AD-1"
+	driver.ListShapesDomainShapes = map[string][]core.Shape{
+		"aaaa:US-ASHBURN-AD-2": {{Shape: common.String("VM.Standard.E4.Flex")}},
+	}
+
+	step := new(stepPreflightValidation)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if err, ok := state.GetOk("error"); ok {
+		t.Fatalf("should not check shapes in skipped availability domains: %s", err)
+	}
+}
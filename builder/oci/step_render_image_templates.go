@@ -0,0 +1,41 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+// stepRenderImageTemplates renders image_name and tags once every generated
+// data is known, that is once the instance is running. Everything named or
+// tagged after them, images and backups alike, is created later on.
+type stepRenderImageTemplates struct{}
+
+func (s *stepRenderImageTemplates) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		ui     = state.Get("ui").(packersdk.Ui)
+		config = state.Get("config").(*Config)
+	)
+
+	var generatedData map[string]interface{}
+	if data, ok := state.GetOk("generated_data"); ok {
+		generatedData = data.(map[string]interface{})
+	}
+	if err := config.renderImageTemplates(generatedData); err != nil {
+		err = fmt.Errorf("Error rendering image templates: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *stepRenderImageTemplates) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
@@ -0,0 +1,58 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepRenderImageTemplates(t *testing.T) {
+	state := testState()
+	state.Put("generated_data", map[string]interface{}{
+		"SourceImageName":      "Oracle-Linux-8.10",
+		"SourceImageOSVersion": "8",
+	})
+
+	config := state.Get("config").(*Config)
+	config.ImageName = "{{ .SourceImageName }}-hardened"
+	config.Tags = map[string]string{"os_version": "{{ build `SourceImageOSVersion` }}"}
+
+	step := new(stepRenderImageTemplates)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if config.ImageName != "Oracle-Linux-8.10-hardened" {
+		t.Errorf("bad image name %q", config.ImageName)
+	}
+	if config.Tags["os_version"] != "8" {
+		t.Errorf("bad tags %v", config.Tags)
+	}
+}
+
+func TestStepRenderImageTemplates_TagTooLong(t *testing.T) {
+	state := testState()
+	state.Put("generated_data", map[string]interface{}{
+		"SourceImageName": strings.Repeat("a", 101),
+	})
+
+	config := state.Get("config").(*Config)
+	config.Tags = map[string]string{"source": "{{ .SourceImageName }}"}
+
+	step := new(stepRenderImageTemplates)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if err, ok := state.GetOk("error"); !ok || !strings.Contains(err.(error).Error(), "Tag value length too long") {
+		t.Fatalf("expected tag length error, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/packerbuilderdata"
+)
+
+// stepSourceImageInfo looks up the image the instance was launched from and
+// exposes it as generated data. The image is read from the instance, as
+// base_image_filter could match a newer image by now.
+type stepSourceImageInfo struct{}
+
+func (s *stepSourceImageInfo) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	var (
+		driver = state.Get("driver").(Driver)
+		ui     = state.Get("ui").(packersdk.Ui)
+		id     = state.Get("instance_id").(string)
+	)
+
+	image, err := driver.GetInstanceSourceImage(ctx, id)
+	if err != nil {
+		err = fmt.Errorf("Error getting source image: %s", err)
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	generatedData := &packerbuilderdata.GeneratedData{State: state}
+	generatedData.Put("SourceImageOCID", *image.Id)
+	generatedData.Put("SourceImageName", imageDisplayName(image))
+	if image.OperatingSystemVersion != nil {
+		generatedData.Put("SourceImageOSVersion", *image.OperatingSystemVersion)
+	} else {
+		generatedData.Put("SourceImageOSVersion", "")
+	}
+
+	ui.Say(fmt.Sprintf("Instance was launched from image %s (%s).", imageDisplayName(image), *image.Id))
+
+	return multistep.ActionContinue
+}
+
+func (s *stepSourceImageInfo) Cleanup(state multistep.StateBag) {
+	// no cleanup
+}
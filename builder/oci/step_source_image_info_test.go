@@ -0,0 +1,58 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+)
+
+func TestStepSourceImageInfo(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1.instance.oc1.iad.aaa")
+	config := state.Get("config").(*Config)
+	driver := state.Get("driver").(*driverMock)
+
+	step := new(stepSourceImageInfo)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	generatedData := state.Get("generated_data").(map[string]interface{})
+	expected := map[string]string{
+		"SourceImageOCID":      config.BaseImageID,
+		"SourceImageName":      "Oracle-Linux-8.10-2025.01.31-0",
+		"SourceImageOSVersion": "8",
+	}
+	for key, value := range expected {
+		if generatedData[key] != value {
+			t.Errorf("expected %s to be %q, got %q", key, value, generatedData[key])
+		}
+	}
+	if driver.GetInstanceSourceImageID != "ocid1.instance.oc1.iad.aaa" {
+		t.Errorf("should have read the image of the instance, got %q", driver.GetInstanceSourceImageID)
+	}
+}
+
+func TestStepSourceImageInfo_GetInstanceSourceImageErr(t *testing.T) {
+	state := testState()
+	state.Put("instance_id", "ocid1.instance.oc1.iad.aaa")
+	driver := state.Get("driver").(*driverMock)
+	driver.GetInstanceSourceImageErr = errors.New("error")
+
+	step := new(stepSourceImageInfo)
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+//go:generate packer-sdc mapstructure-to-hcl2 -type Config,DatasourceOutput
+
+// Package ociavailabilitydomains contains a packersdk.Datasource
+// implementation that lists the availability domains of an Oracle Cloud
+// Infrastructure (OCI) region.
+package ociavailabilitydomains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/hcldec"
+	ocibuilder "github.com/hashicorp/packer-plugin-oracle/builder/oci"
+	"github.com/hashicorp/packer-plugin-sdk/common"
+	"github.com/hashicorp/packer-plugin-sdk/hcl2helper"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/template/config"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type Config struct {
+	common.PackerConfig     `mapstructure:",squash"`
+	ocibuilder.AccessConfig `mapstructure:",squash"`
+
+	// The compartment to list availability domains for. Defaults to the
+	// tenancy.
+	CompartmentID string `mapstructure:"compartment_ocid"`
+}
+
+type Datasource struct {
+	config Config
+}
+
+type DatasourceOutput struct {
+	Names []string `mapstructure:"names"`
+	IDs   []string `mapstructure:"ids"`
+}
+
+func (d *Datasource) ConfigSpec() hcldec.ObjectSpec {
+	return d.config.FlatMapstructure().HCL2Spec()
+}
+
+func (d *Datasource) Configure(raws ...interface{}) error {
+	err := config.Decode(&d.config, nil, raws...)
+	if err != nil {
+		return err
+	}
+
+	var errs *packersdk.MultiError
+	if es := d.config.AccessConfig.Prepare(); len(es) > 0 {
+		errs = packersdk.MultiErrorAppend(errs, es...)
+	}
+
+	if d.config.CompartmentID == "" && errs == nil {
+		tenancyOCID, err := d.config.ConfigProvider().TenancyOCID()
+		if err != nil || tenancyOCID == "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, errors.New("'compartment_ocid' must be specified when the tenancy is unknown"))
+		}
+		d.config.CompartmentID = tenancyOCID
+	}
+
+	if errs != nil && len(errs.Errors) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (d *Datasource) OutputSpec() hcldec.ObjectSpec {
+	return (&DatasourceOutput{}).FlatMapstructure().HCL2Spec()
+}
+
+func (d *Datasource) Execute() (cty.Value, error) {
+	null := cty.NullVal(cty.EmptyObject)
+
+	driver, err := ocibuilder.NewDriverOCI(&ocibuilder.Config{AccessConfig: d.config.AccessConfig})
+	if err != nil {
+		return null, err
+	}
+
+	ads, err := driver.ListAvailabilityDomains(context.TODO(), d.config.CompartmentID)
+	if err != nil {
+		return null, fmt.Errorf("Error listing availability domains: %s", err)
+	}
+	if len(ads) == 0 {
+		return null, errors.New("no availability domains found")
+	}
+
+	output := DatasourceOutput{}
+	for _, ad := range ads {
+		output.Names = append(output.Names, *ad.Name)
+		output.IDs = append(output.IDs, *ad.Id)
+	}
+
+	return hcl2helper.HCL2ValueFromConfig(output, d.OutputSpec()), nil
+}
@@ -0,0 +1,44 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package ociavailabilitydomains
+
+import (
+	"testing"
+
+	ocibuilder "github.com/hashicorp/packer-plugin-oracle/builder/oci"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+func testConfig() map[string]interface{} {
+	return ocibuilder.TestAccessConfig()
+}
+
+func TestDatasource_ImplementsDatasource(t *testing.T) {
+	var _ packersdk.Datasource = new(Datasource)
+}
+
+func TestDatasourceConfigure(t *testing.T) {
+	var d Datasource
+	if err := d.Configure(testConfig()); err != nil {
+		t.Fatalf("Unexpected error in configuration %+v", err)
+	}
+
+	if d.config.CompartmentID != "ocid1.tenancy.oc1..aaa" {
+		t.Errorf("Expected compartment_ocid to default to the tenancy, got %s", d.config.CompartmentID)
+	}
+}
+
+func TestDatasourceConfigure_CompartmentID(t *testing.T) {
+	raw := testConfig()
+	raw["compartment_ocid"] = "ocid1.compartment.oc1..aaa"
+
+	var d Datasource
+	if err := d.Configure(raw); err != nil {
+		t.Fatalf("Unexpected error in configuration %+v", err)
+	}
+
+	if d.config.CompartmentID != "ocid1.compartment.oc1..aaa" {
+		t.Errorf("Expected compartment_ocid ocid1.compartment.oc1..aaa, got %s", d.config.CompartmentID)
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+//go:generate packer-sdc mapstructure-to-hcl2 -type Config,DatasourceOutput
+
+// Package ociimage contains a packersdk.Datasource implementation that looks
+// up Oracle Cloud Infrastructure (OCI) images.
+package ociimage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2/hcldec"
+	ocibuilder "github.com/hashicorp/packer-plugin-oracle/builder/oci"
+	"github.com/hashicorp/packer-plugin-sdk/common"
+	"github.com/hashicorp/packer-plugin-sdk/hcl2helper"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/template/config"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type Config struct {
+	common.PackerConfig     `mapstructure:",squash"`
+	ocibuilder.AccessConfig `mapstructure:",squash"`
+
+	// The compartment to look up images in. Platform images are listed in
+	// every compartment.
+	CompartmentID string `mapstructure:"compartment_ocid" required:"true"`
+
+	// Filters passed to the OCI API.
+	DisplayName            string `mapstructure:"display_name"`
+	OperatingSystem        string `mapstructure:"operating_system"`
+	OperatingSystemVersion string `mapstructure:"operating_system_version"`
+	Shape                  string `mapstructure:"shape"`
+	// One of the image lifecycle states. Defaults to AVAILABLE.
+	LifecycleState string `mapstructure:"lifecycle_state"`
+
+	// Filters applied to the images returned by the OCI API. DefinedTags
+	// keys are of the form <namespace>.<key>.
+	DisplayNameSearch string            `mapstructure:"display_name_search"`
+	Tags              map[string]string `mapstructure:"tags"`
+	DefinedTags       map[string]string `mapstructure:"defined_tags"`
+
+	// If true the newest of several matching images is returned, otherwise
+	// several matches are an error.
+	MostRecent bool `mapstructure:"most_recent"`
+
+	displayNameRe *regexp.Regexp
+}
+
+type Datasource struct {
+	config Config
+}
+
+type DatasourceOutput struct {
+	ID                     string            `mapstructure:"id"`
+	Name                   string            `mapstructure:"name"`
+	CompartmentID          string            `mapstructure:"compartment_ocid"`
+	OperatingSystem        string            `mapstructure:"operating_system"`
+	OperatingSystemVersion string            `mapstructure:"operating_system_version"`
+	TimeCreated            string            `mapstructure:"time_created"`
+	Tags                   map[string]string `mapstructure:"tags"`
+}
+
+func (d *Datasource) ConfigSpec() hcldec.ObjectSpec {
+	return d.config.FlatMapstructure().HCL2Spec()
+}
+
+func (d *Datasource) Configure(raws ...interface{}) error {
+	err := config.Decode(&d.config, nil, raws...)
+	if err != nil {
+		return err
+	}
+
+	var errs *packersdk.MultiError
+	if es := d.config.AccessConfig.Prepare(); len(es) > 0 {
+		errs = packersdk.MultiErrorAppend(errs, es...)
+	}
+
+	if d.config.CompartmentID == "" {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'compartment_ocid' must be specified"))
+	}
+
+	if d.config.LifecycleState == "" {
+		d.config.LifecycleState = string(core.ImageLifecycleStateAvailable)
+	}
+	if _, ok := core.GetMappingImageLifecycleStateEnum(d.config.LifecycleState); !ok {
+		errs = packersdk.MultiErrorAppend(errs, fmt.Errorf("'lifecycle_state' must be one of %s",
+			strings.Join(core.GetImageLifecycleStateEnumStringValues(), ", ")))
+	}
+
+	if d.config.DisplayNameSearch != "" {
+		re, err := regexp.Compile(d.config.DisplayNameSearch)
+		if err != nil {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'display_name_search' is invalid: %s", err))
+		}
+		d.config.displayNameRe = re
+	}
+
+	for key := range d.config.DefinedTags {
+		if namespace, name, ok := strings.Cut(key, "."); !ok || namespace == "" || name == "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'defined_tags' key %q must be of the form <namespace>.<key>", key))
+		}
+	}
+
+	if errs != nil && len(errs.Errors) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (d *Datasource) OutputSpec() hcldec.ObjectSpec {
+	return (&DatasourceOutput{}).FlatMapstructure().HCL2Spec()
+}
+
+func (d *Datasource) Execute() (cty.Value, error) {
+	null := cty.NullVal(cty.EmptyObject)
+
+	driver, err := ocibuilder.NewDriverOCI(&ocibuilder.Config{AccessConfig: d.config.AccessConfig})
+	if err != nil {
+		return null, err
+	}
+
+	request := core.ListImagesRequest{
+		CompartmentId:  &d.config.CompartmentID,
+		LifecycleState: core.ImageLifecycleStateEnum(d.config.LifecycleState),
+	}
+	if d.config.DisplayName != "" {
+		request.DisplayName = &d.config.DisplayName
+	}
+	if d.config.OperatingSystem != "" {
+		request.OperatingSystem = &d.config.OperatingSystem
+	}
+	if d.config.OperatingSystemVersion != "" {
+		request.OperatingSystemVersion = &d.config.OperatingSystemVersion
+	}
+	if d.config.Shape != "" {
+		request.Shape = &d.config.Shape
+	}
+
+	images, err := driver.ListImages(context.TODO(), request)
+	if err != nil {
+		return null, fmt.Errorf("Error listing images: %s", err)
+	}
+
+	image, err := d.config.selectImage(images)
+	if err != nil {
+		return null, err
+	}
+
+	output := DatasourceOutput{
+		ID:                     *image.Id,
+		Name:                   stringValue(image.DisplayName),
+		CompartmentID:          stringValue(image.CompartmentId),
+		OperatingSystem:        stringValue(image.OperatingSystem),
+		OperatingSystemVersion: stringValue(image.OperatingSystemVersion),
+		Tags:                   image.FreeformTags,
+	}
+	if image.TimeCreated != nil {
+		output.TimeCreated = image.TimeCreated.Format(time.RFC3339)
+	}
+
+	return hcl2helper.HCL2ValueFromConfig(output, d.OutputSpec()), nil
+}
+
+// selectImage returns the image matching the filters applied on the client
+// side.
+func (c *Config) selectImage(images []core.Image) (core.Image, error) {
+	var matches []core.Image
+	for _, image := range images {
+		if c.matches(image) {
+			matches = append(matches, image)
+		}
+	}
+
+	if len(matches) == 0 {
+		return core.Image{}, errors.New("no image matched the filters")
+	}
+	if len(matches) > 1 && !c.MostRecent {
+		return core.Image{}, fmt.Errorf("%d images matched the filters, set 'most_recent' to use the newest one", len(matches))
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return timeCreated(matches[i]).After(timeCreated(matches[j]))
+	})
+
+	return matches[0], nil
+}
+
+func (c *Config) matches(image core.Image) bool {
+	if c.displayNameRe != nil && !c.displayNameRe.MatchString(stringValue(image.DisplayName)) {
+		return false
+	}
+
+	for key, value := range c.Tags {
+		if image.FreeformTags[key] != value {
+			return false
+		}
+	}
+
+	for key, value := range c.DefinedTags {
+		namespace, name, _ := strings.Cut(key, ".")
+		actual, ok := image.DefinedTags[namespace][name]
+		if !ok || fmt.Sprint(actual) != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func timeCreated(image core.Image) time.Time {
+	if image.TimeCreated == nil {
+		return time.Time{}
+	}
+	return image.TimeCreated.Time
+}
@@ -0,0 +1,137 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package ociimage
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	ocibuilder "github.com/hashicorp/packer-plugin-oracle/builder/oci"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func testConfig() map[string]interface{} {
+	config := ocibuilder.TestAccessConfig()
+	config["compartment_ocid"] = "ocid1.compartment.oc1..aaa"
+	return config
+}
+
+func testImage(id, name string, age time.Duration, tags map[string]string, definedTags map[string]map[string]interface{}) core.Image {
+	return core.Image{
+		Id:           common.String(id),
+		DisplayName:  common.String(name),
+		TimeCreated:  &common.SDKTime{Time: time.Now().Add(-age)},
+		FreeformTags: tags,
+		DefinedTags:  definedTags,
+	}
+}
+
+func TestDatasource_ImplementsDatasource(t *testing.T) {
+	var _ packersdk.Datasource = new(Datasource)
+}
+
+func TestDatasourceConfigure(t *testing.T) {
+	var d Datasource
+	if err := d.Configure(testConfig()); err != nil {
+		t.Fatalf("Unexpected error in configuration %+v", err)
+	}
+
+	if d.config.LifecycleState != "AVAILABLE" {
+		t.Errorf("Expected default lifecycle_state AVAILABLE, got %s", d.config.LifecycleState)
+	}
+}
+
+func TestDatasourceConfigure_Invalid(t *testing.T) {
+	raw := testConfig()
+	delete(raw, "compartment_ocid")
+	raw["lifecycle_state"] = "GONE"
+	raw["display_name_search"] = "Oracle-Linux-("
+	raw["defined_tags"] = map[string]string{"Operations": "CostCenter"}
+
+	var d Datasource
+	err := d.Configure(raw)
+	if err == nil {
+		t.Fatal("Expected errors but got none")
+	}
+
+	for _, expected := range []string{
+		"'compartment_ocid' must be specified",
+		"'lifecycle_state' must be one of",
+		"'display_name_search' is invalid",
+		"'defined_tags' key \"Operations\" must be of the form <namespace>.<key>",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q to contain '%s'", err, expected)
+		}
+	}
+}
+
+func TestConfigSelectImage(t *testing.T) {
+	images := []core.Image{
+		testImage("ocid1.image.1", "Oracle-Linux-8.9", 48*time.Hour, map[string]string{"team": "web"}, nil),
+		testImage("ocid1.image.2", "Oracle-Linux-8.10", 24*time.Hour, map[string]string{"team": "web"},
+			map[string]map[string]interface{}{"Operations": {"CostCenter": "42"}}),
+		testImage("ocid1.image.3", "Oracle-Linux-9.4", time.Hour, map[string]string{"team": "db"}, nil),
+	}
+
+	for name, tc := range map[string]struct {
+		raw      map[string]interface{}
+		expected string
+		err      string
+	}{
+		"most recent": {
+			raw:      map[string]interface{}{"most_recent": true},
+			expected: "ocid1.image.3",
+		},
+		"display name search": {
+			raw:      map[string]interface{}{"display_name_search": `^Oracle-Linux-8\.`, "most_recent": true},
+			expected: "ocid1.image.2",
+		},
+		"tags": {
+			raw:      map[string]interface{}{"tags": map[string]string{"team": "db"}},
+			expected: "ocid1.image.3",
+		},
+		"defined tags": {
+			raw:      map[string]interface{}{"defined_tags": map[string]string{"Operations.CostCenter": "42"}},
+			expected: "ocid1.image.2",
+		},
+		"several matches": {
+			raw: map[string]interface{}{"tags": map[string]string{"team": "web"}},
+			err: "2 images matched the filters, set 'most_recent'",
+		},
+		"no match": {
+			raw: map[string]interface{}{"tags": map[string]string{"team": "ops"}},
+			err: "no image matched the filters",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			raw := testConfig()
+			for k, v := range tc.raw {
+				raw[k] = v
+			}
+
+			var d Datasource
+			if err := d.Configure(raw); err != nil {
+				t.Fatalf("Unexpected error in configuration %+v", err)
+			}
+
+			image, err := d.config.selectImage(images)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("Expected error '%s', got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error %s", err)
+			}
+			if *image.Id != tc.expected {
+				t.Errorf("Expected image %s, got %s", tc.expected, *image.Id)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+//go:generate packer-sdc mapstructure-to-hcl2 -type Config,DatasourceOutput
+
+// Package ocisecret contains a packersdk.Datasource implementation that reads
+// Oracle Cloud Infrastructure (OCI) Vault secrets.
+package ocisecret
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hcldec"
+	ocibuilder "github.com/hashicorp/packer-plugin-oracle/builder/oci"
+	"github.com/hashicorp/packer-plugin-sdk/common"
+	"github.com/hashicorp/packer-plugin-sdk/hcl2helper"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/template/config"
+	"github.com/oracle/oci-go-sdk/v65/secrets"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type Config struct {
+	common.PackerConfig     `mapstructure:",squash"`
+	ocibuilder.AccessConfig `mapstructure:",squash"`
+
+	// The secret is given either by its OCID, or by its name and the OCID of
+	// its vault.
+	SecretID   string `mapstructure:"secret_ocid"`
+	SecretName string `mapstructure:"secret_name"`
+	VaultID    string `mapstructure:"vault_ocid"`
+
+	// The stage of the version to read. Defaults to the current version.
+	Stage string `mapstructure:"stage"`
+}
+
+type Datasource struct {
+	config Config
+}
+
+type DatasourceOutput struct {
+	Value         string `mapstructure:"value"`
+	SecretID      string `mapstructure:"secret_ocid"`
+	VersionNumber int64  `mapstructure:"version_number"`
+}
+
+func (d *Datasource) ConfigSpec() hcldec.ObjectSpec {
+	return d.config.FlatMapstructure().HCL2Spec()
+}
+
+func (d *Datasource) Configure(raws ...interface{}) error {
+	err := config.Decode(&d.config, nil, raws...)
+	if err != nil {
+		return err
+	}
+
+	var errs *packersdk.MultiError
+	if es := d.config.AccessConfig.Prepare(); len(es) > 0 {
+		errs = packersdk.MultiErrorAppend(errs, es...)
+	}
+
+	byName := d.config.SecretName != "" || d.config.VaultID != ""
+	switch {
+	case d.config.SecretID != "" && byName:
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("only one of 'secret_ocid' or 'secret_name' and 'vault_ocid' can be specified"))
+	case d.config.SecretID == "" && !byName:
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'secret_ocid' or 'secret_name' and 'vault_ocid' must be specified"))
+	case byName && (d.config.SecretName == "" || d.config.VaultID == ""):
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'secret_name' and 'vault_ocid' must be specified together"))
+	}
+
+	d.config.Stage = strings.ToUpper(d.config.Stage)
+	if _, ok := secrets.GetMappingGetSecretBundleStageEnum(d.config.Stage); d.config.Stage != "" && !ok {
+		errs = packersdk.MultiErrorAppend(errs, fmt.Errorf("'stage' must be one of %s",
+			strings.Join(secrets.GetGetSecretBundleStageEnumStringValues(), ", ")))
+	}
+
+	if errs != nil && len(errs.Errors) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (d *Datasource) OutputSpec() hcldec.ObjectSpec {
+	return (&DatasourceOutput{}).FlatMapstructure().HCL2Spec()
+}
+
+func (d *Datasource) Execute() (cty.Value, error) {
+	null := cty.NullVal(cty.EmptyObject)
+	ctx := context.TODO()
+
+	driver, err := ocibuilder.NewDriverOCI(&ocibuilder.Config{AccessConfig: d.config.AccessConfig})
+	if err != nil {
+		return null, err
+	}
+
+	var bundle secrets.SecretBundle
+	if d.config.SecretID != "" {
+		bundle, err = driver.GetSecretBundle(ctx, d.config.SecretID, d.config.Stage)
+	} else {
+		bundle, err = driver.GetSecretBundleByName(ctx, d.config.VaultID, d.config.SecretName, d.config.Stage)
+	}
+	if err != nil {
+		return null, fmt.Errorf("Error reading secret: %s", err)
+	}
+
+	output, err := secretOutput(bundle)
+	if err != nil {
+		return null, err
+	}
+
+	return hcl2helper.HCL2ValueFromConfig(output, d.OutputSpec()), nil
+}
+
+// secretOutput builds the data source output from the secret bundle. Fields
+// the API leaves empty are returned as zero values.
+func secretOutput(bundle secrets.SecretBundle) (DatasourceOutput, error) {
+	value, err := secretValue(bundle)
+	if err != nil {
+		return DatasourceOutput{}, err
+	}
+
+	output := DatasourceOutput{
+		Value:    value,
+		SecretID: stringValue(bundle.SecretId),
+	}
+	if bundle.VersionNumber != nil {
+		output.VersionNumber = *bundle.VersionNumber
+	}
+
+	return output, nil
+}
+
+// secretValue decodes the content of the secret bundle and registers it with
+// the log filter, so that it never shows in the output.
+func secretValue(bundle secrets.SecretBundle) (string, error) {
+	content, ok := bundle.SecretBundleContent.(secrets.Base64SecretBundleContentDetails)
+	if !ok || content.Content == nil {
+		return "", fmt.Errorf("secret %s has no base64 content", stringValue(bundle.SecretId))
+	}
+
+	value, err := base64.StdEncoding.DecodeString(*content.Content)
+	if err != nil {
+		return "", fmt.Errorf("Error decoding secret %s: %s", stringValue(bundle.SecretId), err)
+	}
+
+	packersdk.LogSecretFilter.Set(string(value))
+
+	return string(value), nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
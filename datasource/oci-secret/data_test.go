@@ -0,0 +1,146 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package ocisecret
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	ocibuilder "github.com/hashicorp/packer-plugin-oracle/builder/oci"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/secrets"
+)
+
+func testConfig() map[string]interface{} {
+	return ocibuilder.TestAccessConfig()
+}
+
+func TestDatasource_ImplementsDatasource(t *testing.T) {
+	var _ packersdk.Datasource = new(Datasource)
+}
+
+func TestDatasourceConfigure(t *testing.T) {
+	for name, secret := range map[string]map[string]interface{}{
+		"by ocid": {"secret_ocid": "ocid1.vaultsecret.oc1..aaa", "stage": "latest"},
+		"by name": {"secret_name": "winrm-password", "vault_ocid": "ocid1.vault.oc1..aaa"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			raw := testConfig()
+			for k, v := range secret {
+				raw[k] = v
+			}
+
+			var d Datasource
+			if err := d.Configure(raw); err != nil {
+				t.Fatalf("Unexpected error in configuration %+v", err)
+			}
+		})
+	}
+}
+
+func TestDatasourceConfigure_Invalid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		secret   map[string]interface{}
+		expected string
+	}{
+		"missing": {
+			secret:   map[string]interface{}{},
+			expected: "'secret_ocid' or 'secret_name' and 'vault_ocid' must be specified",
+		},
+		"both": {
+			secret:   map[string]interface{}{"secret_ocid": "ocid1.vaultsecret.oc1..aaa", "secret_name": "winrm-password"},
+			expected: "only one of 'secret_ocid' or 'secret_name' and 'vault_ocid' can be specified",
+		},
+		"name without vault": {
+			secret:   map[string]interface{}{"secret_name": "winrm-password"},
+			expected: "'secret_name' and 'vault_ocid' must be specified together",
+		},
+		"stage": {
+			secret:   map[string]interface{}{"secret_ocid": "ocid1.vaultsecret.oc1..aaa", "stage": "NEXT"},
+			expected: "'stage' must be one of",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			raw := testConfig()
+			for k, v := range tc.secret {
+				raw[k] = v
+			}
+
+			var d Datasource
+			err := d.Configure(raw)
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Fatalf("Expected error '%s', got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestSecretValue(t *testing.T) {
+	bundle := secrets.SecretBundle{
+		SecretId: common.String("ocid1.vaultsecret.oc1..aaa"),
+		SecretBundleContent: secrets.Base64SecretBundleContentDetails{
+			Content: common.String(base64.StdEncoding.EncodeToString([]byte("s3cr3t-v4lu3"))),
+		},
+	}
+
+	value, err := secretValue(bundle)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if value != "s3cr3t-v4lu3" {
+		t.Errorf("Expected decoded value, got %q", value)
+	}
+
+	if filtered := packersdk.LogSecretFilter.FilterString("password is s3cr3t-v4lu3"); strings.Contains(filtered, "s3cr3t-v4lu3") {
+		t.Errorf("Secret should be filtered from logs, got %q", filtered)
+	}
+}
+
+func TestSecretValue_NoContent(t *testing.T) {
+	bundle := secrets.SecretBundle{SecretId: common.String("ocid1.vaultsecret.oc1..aaa")}
+
+	if _, err := secretValue(bundle); err == nil {
+		t.Fatal("Expected an error for a secret without content")
+	}
+}
+
+func TestSecretOutput(t *testing.T) {
+	bundle := secrets.SecretBundle{
+		SecretId:      common.String("ocid1.vaultsecret.oc1..aaa"),
+		VersionNumber: common.Int64(3),
+		SecretBundleContent: secrets.Base64SecretBundleContentDetails{
+			Content: common.String(base64.StdEncoding.EncodeToString([]byte("value"))),
+		},
+	}
+
+	output, err := secretOutput(bundle)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if output.SecretID != "ocid1.vaultsecret.oc1..aaa" || output.VersionNumber != 3 || output.Value != "value" {
+		t.Errorf("Unexpected output %+v", output)
+	}
+}
+
+func TestSecretOutput_MissingFields(t *testing.T) {
+	bundle := secrets.SecretBundle{
+		SecretBundleContent: secrets.Base64SecretBundleContentDetails{
+			Content: common.String(base64.StdEncoding.EncodeToString([]byte("value"))),
+		},
+	}
+
+	output, err := secretOutput(bundle)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if output.SecretID != "" || output.VersionNumber != 0 {
+		t.Errorf("Expected zero values for missing fields, got %+v", output)
+	}
+
+	if _, err := secretValue(secrets.SecretBundle{}); err == nil {
+		t.Fatal("Expected an error for a secret without content")
+	}
+}
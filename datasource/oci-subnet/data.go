@@ -0,0 +1,201 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+//go:generate packer-sdc mapstructure-to-hcl2 -type Config,DatasourceOutput
+
+// Package ocisubnet contains a packersdk.Datasource implementation that looks
+// up an Oracle Cloud Infrastructure (OCI) subnet and network security groups
+// of its VCN.
+package ocisubnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/hcldec"
+	ocibuilder "github.com/hashicorp/packer-plugin-oracle/builder/oci"
+	"github.com/hashicorp/packer-plugin-sdk/common"
+	"github.com/hashicorp/packer-plugin-sdk/hcl2helper"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/template/config"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type Config struct {
+	common.PackerConfig     `mapstructure:",squash"`
+	ocibuilder.AccessConfig `mapstructure:",squash"`
+
+	// The compartment of the subnet.
+	CompartmentID string `mapstructure:"compartment_ocid" required:"true"`
+
+	// Filters selecting exactly one subnet.
+	VcnID       string            `mapstructure:"vcn_ocid"`
+	DisplayName string            `mapstructure:"display_name"`
+	Tags        map[string]string `mapstructure:"tags"`
+
+	// Display names of network security groups of the subnet's VCN to look
+	// up.
+	NsgDisplayNames []string `mapstructure:"nsg_display_names"`
+}
+
+type Datasource struct {
+	config Config
+}
+
+type DatasourceOutput struct {
+	ID                     string   `mapstructure:"id"`
+	Name                   string   `mapstructure:"name"`
+	VcnID                  string   `mapstructure:"vcn_ocid"`
+	CidrBlock              string   `mapstructure:"cidr_block"`
+	AvailabilityDomain     string   `mapstructure:"availability_domain"`
+	ProhibitPublicIpOnVnic bool     `mapstructure:"prohibit_public_ip_on_vnic"`
+	NsgIDs                 []string `mapstructure:"nsg_ids"`
+}
+
+func (d *Datasource) ConfigSpec() hcldec.ObjectSpec {
+	return d.config.FlatMapstructure().HCL2Spec()
+}
+
+func (d *Datasource) Configure(raws ...interface{}) error {
+	err := config.Decode(&d.config, nil, raws...)
+	if err != nil {
+		return err
+	}
+
+	var errs *packersdk.MultiError
+	if es := d.config.AccessConfig.Prepare(); len(es) > 0 {
+		errs = packersdk.MultiErrorAppend(errs, es...)
+	}
+
+	if d.config.CompartmentID == "" {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'compartment_ocid' must be specified"))
+	}
+
+	for i, name := range d.config.NsgDisplayNames {
+		if name == "" {
+			errs = packersdk.MultiErrorAppend(
+				errs, fmt.Errorf("'nsg_display_names[%d]' must not be empty", i))
+		}
+	}
+
+	if errs != nil && len(errs.Errors) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (d *Datasource) OutputSpec() hcldec.ObjectSpec {
+	return (&DatasourceOutput{}).FlatMapstructure().HCL2Spec()
+}
+
+func (d *Datasource) Execute() (cty.Value, error) {
+	null := cty.NullVal(cty.EmptyObject)
+	ctx := context.TODO()
+
+	driver, err := ocibuilder.NewDriverOCI(&ocibuilder.Config{AccessConfig: d.config.AccessConfig})
+	if err != nil {
+		return null, err
+	}
+
+	subnets, err := driver.ListSubnets(ctx, d.config.CompartmentID, d.config.VcnID)
+	if err != nil {
+		return null, fmt.Errorf("Error listing subnets: %s", err)
+	}
+
+	subnet, err := d.config.selectSubnet(subnets)
+	if err != nil {
+		return null, err
+	}
+
+	output := DatasourceOutput{
+		ID:        *subnet.Id,
+		Name:      stringValue(subnet.DisplayName),
+		VcnID:     stringValue(subnet.VcnId),
+		CidrBlock: stringValue(subnet.CidrBlock),
+		// Empty for regional subnets.
+		AvailabilityDomain: stringValue(subnet.AvailabilityDomain),
+	}
+	if subnet.ProhibitPublicIpOnVnic != nil {
+		output.ProhibitPublicIpOnVnic = *subnet.ProhibitPublicIpOnVnic
+	}
+
+	if len(d.config.NsgDisplayNames) > 0 {
+		groups, err := driver.ListNetworkSecurityGroups(ctx, *subnet.VcnId)
+		if err != nil {
+			return null, fmt.Errorf("Error listing network security groups: %s", err)
+		}
+		if output.NsgIDs, err = d.config.nsgIDs(groups); err != nil {
+			return null, err
+		}
+	}
+
+	return hcl2helper.HCL2ValueFromConfig(output, d.OutputSpec()), nil
+}
+
+// selectSubnet returns the only subnet matching the filters.
+func (c *Config) selectSubnet(subnets []core.Subnet) (core.Subnet, error) {
+	var matches []core.Subnet
+	for _, subnet := range subnets {
+		if c.DisplayName != "" && stringValue(subnet.DisplayName) != c.DisplayName {
+			continue
+		}
+		if !hasTags(subnet.FreeformTags, c.Tags) {
+			continue
+		}
+		matches = append(matches, subnet)
+	}
+
+	switch len(matches) {
+	case 0:
+		return core.Subnet{}, errors.New("no subnet matched the filters")
+	case 1:
+		return matches[0], nil
+	default:
+		return core.Subnet{}, fmt.Errorf("%d subnets matched the filters, narrow them down with 'vcn_ocid', 'display_name' or 'tags'", len(matches))
+	}
+}
+
+// nsgIDs returns the OCIDs of the network security groups named in
+// NsgDisplayNames, in the same order.
+func (c *Config) nsgIDs(groups []core.NetworkSecurityGroup) ([]string, error) {
+	var ids []string
+	for _, name := range c.NsgDisplayNames {
+		var found []string
+		for _, group := range groups {
+			if stringValue(group.DisplayName) == name {
+				found = append(found, *group.Id)
+			}
+		}
+
+		switch len(found) {
+		case 0:
+			return nil, fmt.Errorf("no network security group named %q found", name)
+		case 1:
+			ids = append(ids, found[0])
+		default:
+			return nil, fmt.Errorf("%d network security groups are named %q", len(found), name)
+		}
+	}
+
+	return ids, nil
+}
+
+func hasTags(tags map[string]string, want map[string]string) bool {
+	for key, value := range want {
+		if tags[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
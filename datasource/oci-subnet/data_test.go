@@ -0,0 +1,121 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package ocisubnet
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	ocibuilder "github.com/hashicorp/packer-plugin-oracle/builder/oci"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func testConfig() map[string]interface{} {
+	config := ocibuilder.TestAccessConfig()
+	config["compartment_ocid"] = "ocid1.compartment.oc1..aaa"
+	return config
+}
+
+func TestDatasource_ImplementsDatasource(t *testing.T) {
+	var _ packersdk.Datasource = new(Datasource)
+}
+
+func TestDatasourceConfigure_Invalid(t *testing.T) {
+	raw := testConfig()
+	delete(raw, "compartment_ocid")
+	raw["nsg_display_names"] = []string{"web", ""}
+
+	var d Datasource
+	err := d.Configure(raw)
+	if err == nil {
+		t.Fatal("Expected errors but got none")
+	}
+
+	for _, expected := range []string{
+		"'compartment_ocid' must be specified",
+		"'nsg_display_names[1]' must not be empty",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q to contain '%s'", err, expected)
+		}
+	}
+}
+
+func TestConfigSelectSubnet(t *testing.T) {
+	subnets := []core.Subnet{
+		{Id: common.String("ocid1.subnet.1"), DisplayName: common.String("public"), FreeformTags: map[string]string{"tier": "web"}},
+		{Id: common.String("ocid1.subnet.2"), DisplayName: common.String("private"), FreeformTags: map[string]string{"tier": "web"}},
+		{Id: common.String("ocid1.subnet.3"), DisplayName: common.String("private"), FreeformTags: map[string]string{"tier": "db"}},
+	}
+
+	for name, tc := range map[string]struct {
+		config   Config
+		expected string
+		err      string
+	}{
+		"display name": {
+			config:   Config{DisplayName: "public"},
+			expected: "ocid1.subnet.1",
+		},
+		"display name and tags": {
+			config:   Config{DisplayName: "private", Tags: map[string]string{"tier": "db"}},
+			expected: "ocid1.subnet.3",
+		},
+		"several matches": {
+			config: Config{DisplayName: "private"},
+			err:    "2 subnets matched the filters",
+		},
+		"no match": {
+			config: Config{DisplayName: "backup"},
+			err:    "no subnet matched the filters",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			subnet, err := tc.config.selectSubnet(subnets)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("Expected error '%s', got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error %s", err)
+			}
+			if *subnet.Id != tc.expected {
+				t.Errorf("Expected subnet %s, got %s", tc.expected, *subnet.Id)
+			}
+		})
+	}
+}
+
+func TestConfigNsgIDs(t *testing.T) {
+	groups := []core.NetworkSecurityGroup{
+		{Id: common.String("ocid1.nsg.1"), DisplayName: common.String("ssh")},
+		{Id: common.String("ocid1.nsg.2"), DisplayName: common.String("web")},
+		{Id: common.String("ocid1.nsg.3"), DisplayName: common.String("dup")},
+		{Id: common.String("ocid1.nsg.4"), DisplayName: common.String("dup")},
+	}
+
+	c := Config{NsgDisplayNames: []string{"web", "ssh"}}
+	ids, err := c.nsgIDs(groups)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if expected := []string{"ocid1.nsg.2", "ocid1.nsg.1"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected %v, got %v", expected, ids)
+	}
+
+	c = Config{NsgDisplayNames: []string{"dup"}}
+	if _, err := c.nsgIDs(groups); err == nil || !strings.Contains(err.Error(), "2 network security groups are named \"dup\"") {
+		t.Errorf("Expected an ambiguous name error, got %v", err)
+	}
+
+	c = Config{NsgDisplayNames: []string{"db"}}
+	if _, err := c.nsgIDs(groups); err == nil || !strings.Contains(err.Error(), "no network security group named \"db\" found") {
+		t.Errorf("Expected a missing name error, got %v", err)
+	}
+}
@@ -8,7 +8,7 @@ require (
 	github.com/hashicorp/go-oracle-terraform v0.17.0
 	github.com/hashicorp/hcl/v2 v2.19.1
 	github.com/hashicorp/packer-plugin-sdk v0.6.4
-	github.com/oracle/oci-go-sdk/v65 v65.4.0
+	github.com/oracle/oci-go-sdk/v65 v65.28.3
 	github.com/smartystreets/goconvey v1.6.4 // indirect
 	github.com/stretchr/testify v1.10.0
 	github.com/zclconf/go-cty v1.13.3
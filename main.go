@@ -11,6 +11,12 @@ import (
 
 	classicbuilder "github.com/hashicorp/packer-plugin-oracle/builder/classic"
 	ocibuilder "github.com/hashicorp/packer-plugin-oracle/builder/oci"
+	ociavailabilitydomains "github.com/hashicorp/packer-plugin-oracle/datasource/oci-availability-domains"
+	ociimage "github.com/hashicorp/packer-plugin-oracle/datasource/oci-image"
+	ocisecret "github.com/hashicorp/packer-plugin-oracle/datasource/oci-secret"
+	ocisubnet "github.com/hashicorp/packer-plugin-oracle/datasource/oci-subnet"
+	ociexport "github.com/hashicorp/packer-plugin-oracle/post-processor/oci-export"
+	ociimport "github.com/hashicorp/packer-plugin-oracle/post-processor/oci-import"
 	"github.com/hashicorp/packer-plugin-oracle/version"
 )
 
@@ -18,6 +24,12 @@ func main() {
 	pps := plugin.NewSet()
 	pps.RegisterBuilder("classic", new(classicbuilder.Builder))
 	pps.RegisterBuilder("oci", new(ocibuilder.Builder))
+	pps.RegisterDatasource("oci-availability-domains", new(ociavailabilitydomains.Datasource))
+	pps.RegisterDatasource("oci-image", new(ociimage.Datasource))
+	pps.RegisterDatasource("oci-secret", new(ocisecret.Datasource))
+	pps.RegisterDatasource("oci-subnet", new(ocisubnet.Datasource))
+	pps.RegisterPostProcessor("oci-export", new(ociexport.PostProcessor))
+	pps.RegisterPostProcessor("oci-import", new(ociimport.PostProcessor))
 	pps.SetVersion(version.PluginVersion)
 	err := pps.Run()
 	if err != nil {
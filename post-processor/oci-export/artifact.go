@@ -0,0 +1,67 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package ociexport
+
+import (
+	"context"
+	"fmt"
+
+	ocibuilder "github.com/hashicorp/packer-plugin-oracle/builder/oci"
+)
+
+// Artifact is an artifact implementation that contains an image exported to
+// Object Storage.
+type Artifact struct {
+	ImageID    string
+	ObjectURI  string
+	Namespace  string
+	BucketName string
+	ObjectName string
+	// PreauthenticatedRequestURL is only set if a pre-authenticated request
+	// was requested.
+	PreauthenticatedRequestURL string
+
+	driver ocibuilder.Driver
+}
+
+// BuilderId uniquely identifies the post-processor.
+func (a *Artifact) BuilderId() string {
+	return BuilderId
+}
+
+// Files lists the files associated with an artifact. We don't have any files
+// as the exported image is stored in Object Storage.
+func (a *Artifact) Files() []string {
+	return nil
+}
+
+// Id returns the URI of the exported object.
+func (a *Artifact) Id() string {
+	return a.ObjectURI
+}
+
+func (a *Artifact) String() string {
+	s := fmt.Sprintf("Image %s was exported to %s", a.ImageID, a.ObjectURI)
+	if a.PreauthenticatedRequestURL != "" {
+		s += fmt.Sprintf("\nPre-authenticated request URL: %s", a.PreauthenticatedRequestURL)
+	}
+
+	return s
+}
+
+func (a *Artifact) State(name string) interface{} {
+	switch name {
+	case "object_uri":
+		return a.ObjectURI
+	case "preauthenticated_request_url":
+		return a.PreauthenticatedRequestURL
+	}
+
+	return nil
+}
+
+// Destroy deletes the exported object.
+func (a *Artifact) Destroy() error {
+	return a.driver.DeleteObject(context.TODO(), a.Namespace, a.BucketName, a.ObjectName)
+}
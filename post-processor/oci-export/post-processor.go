@@ -0,0 +1,210 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+//go:generate packer-sdc mapstructure-to-hcl2 -type Config
+
+// Package ociexport contains a packersdk.PostProcessor implementation that
+// exports Oracle Cloud Infrastructure (OCI) custom images to Object Storage.
+package ociexport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2/hcldec"
+	ocibuilder "github.com/hashicorp/packer-plugin-oracle/builder/oci"
+	"github.com/hashicorp/packer-plugin-sdk/common"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/template/config"
+	"github.com/hashicorp/packer-plugin-sdk/template/interpolate"
+)
+
+// BuilderId uniquely identifies the post-processor
+const BuilderId = "packer.post-processor.oracle-oci-export"
+
+var exportFormats = []string{"OCI", "QCOW2", "VMDK", "VDI", "VHD"}
+
+type Config struct {
+	common.PackerConfig     `mapstructure:",squash"`
+	ocibuilder.AccessConfig `mapstructure:",squash"`
+
+	// Object Storage location of the exported image. The namespace defaults
+	// to the namespace of the tenancy.
+	Namespace  string `mapstructure:"namespace"`
+	BucketName string `mapstructure:"bucket_name" required:"true"`
+	ObjectName string `mapstructure:"object_name"`
+
+	// One of OCI, QCOW2, VMDK, VDI or VHD. Defaults to OCI.
+	ExportFormat string `mapstructure:"export_format"`
+
+	// If true a pre-authenticated request granting read access to the
+	// exported object is created. It expires after
+	// PreauthenticatedRequestExpiry, 24 hours by default.
+	CreatePreauthenticatedRequest bool          `mapstructure:"create_preauthenticated_request"`
+	PreauthenticatedRequestExpiry time.Duration `mapstructure:"preauthenticated_request_expiry"`
+
+	ctx interpolate.Context
+}
+
+type PostProcessor struct {
+	config Config
+}
+
+func (p *PostProcessor) ConfigSpec() hcldec.ObjectSpec { return p.config.FlatMapstructure().HCL2Spec() }
+
+func (p *PostProcessor) Configure(raws ...interface{}) error {
+	err := config.Decode(&p.config, &config.DecodeOpts{
+		PluginType:         BuilderId,
+		Interpolate:        true,
+		InterpolateContext: &p.config.ctx,
+	}, raws...)
+	if err != nil {
+		return err
+	}
+
+	var errs *packersdk.MultiError
+	if es := p.config.AccessConfig.Prepare(); len(es) > 0 {
+		errs = packersdk.MultiErrorAppend(errs, es...)
+	}
+
+	if p.config.BucketName == "" {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'bucket_name' must be specified"))
+	}
+
+	if p.config.ExportFormat == "" {
+		p.config.ExportFormat = "OCI"
+	}
+	p.config.ExportFormat = strings.ToUpper(p.config.ExportFormat)
+
+	validFormat := false
+	for _, format := range exportFormats {
+		if p.config.ExportFormat == format {
+			validFormat = true
+		}
+	}
+	if !validFormat {
+		errs = packersdk.MultiErrorAppend(
+			errs, fmt.Errorf("'export_format' must be one of %s", strings.Join(exportFormats, ", ")))
+	}
+
+	if p.config.ObjectName == "" {
+		name, err := interpolate.Render("packer-{{timestamp}}", nil)
+		if err != nil {
+			errs = packersdk.MultiErrorAppend(errs,
+				fmt.Errorf("unable to parse object name: %s", err))
+		} else {
+			p.config.ObjectName = fmt.Sprintf("%s.%s", name, strings.ToLower(p.config.ExportFormat))
+		}
+	}
+
+	if p.config.PreauthenticatedRequestExpiry == 0 {
+		p.config.PreauthenticatedRequestExpiry = 24 * time.Hour
+	}
+	if p.config.PreauthenticatedRequestExpiry < 0 {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'preauthenticated_request_expiry' must be positive"))
+	}
+
+	if errs != nil && len(errs.Errors) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (p *PostProcessor) PostProcess(ctx context.Context, ui packersdk.Ui, artifact packersdk.Artifact) (packersdk.Artifact, bool, bool, error) {
+	if artifact.BuilderId() != ocibuilder.BuilderId {
+		err := fmt.Errorf(
+			"Unknown artifact type: %s\nCan only export from Oracle OCI builder artifacts.",
+			artifact.BuilderId())
+		return nil, false, false, err
+	}
+
+	region, err := p.config.ConfigProvider().Region()
+	if err != nil {
+		return nil, false, false, err
+	}
+
+	imageID, err := imageForRegion(artifact, region)
+	if err != nil {
+		return nil, false, false, err
+	}
+
+	driver, err := ocibuilder.NewDriverOCI(&ocibuilder.Config{AccessConfig: p.config.AccessConfig})
+	if err != nil {
+		return nil, false, false, err
+	}
+
+	namespace := p.config.Namespace
+	if namespace == "" {
+		namespace, err = driver.GetNamespace(ctx)
+		if err != nil {
+			return nil, false, false, err
+		}
+	}
+
+	ui.Say(fmt.Sprintf("Exporting image %s to '%s/%s' as %s...",
+		imageID, p.config.BucketName, p.config.ObjectName, p.config.ExportFormat))
+
+	objectURI, err := driver.ExportImage(ctx, imageID, namespace, p.config.BucketName, p.config.ObjectName, p.config.ExportFormat)
+	if err != nil {
+		return nil, false, false, fmt.Errorf("Error exporting image: %s", err)
+	}
+
+	ui.Say(fmt.Sprintf("Exported image to %s.", objectURI))
+
+	exported := &Artifact{
+		ImageID:    imageID,
+		ObjectURI:  objectURI,
+		Namespace:  namespace,
+		BucketName: p.config.BucketName,
+		ObjectName: p.config.ObjectName,
+		driver:     driver,
+	}
+
+	if p.config.CreatePreauthenticatedRequest {
+		ui.Say("Creating pre-authenticated request...")
+		url, err := driver.CreateObjectReadURI(ctx, namespace, p.config.BucketName, p.config.ObjectName,
+			time.Now().Add(p.config.PreauthenticatedRequestExpiry))
+		if err != nil {
+			return nil, false, false, err
+		}
+		exported.PreauthenticatedRequestURL = url
+	}
+
+	// The image is left untouched by the export so it is kept unless the
+	// user asks otherwise.
+	return exported, true, false, nil
+}
+
+// imageForRegion returns the OCID of the artifact's image living in region.
+func imageForRegion(artifact packersdk.Artifact, region string) (string, error) {
+	var images map[string]string
+	switch v := artifact.State("images").(type) {
+	case map[string]string:
+		images = v
+	case *map[string]string:
+		images = *v
+	}
+
+	// Artifacts without the images state hold a single image, whose OCID is
+	// their ID.
+	if len(images) == 0 {
+		id := artifact.Id()
+		if !strings.HasPrefix(id, "ocid1.image.") || strings.ContainsAny(id, ", ") {
+			return "", fmt.Errorf("artifact ID %q is not the OCID of an image", id)
+		}
+		return id, nil
+	}
+
+	id, ok := images[region]
+	if !ok {
+		return "", fmt.Errorf("artifact has no image in region %s", region)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,79 @@
+// Code generated by "packer-sdc mapstructure-to-hcl2"; DO NOT EDIT.
+
+package ociexport
+
+import (
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// FlatConfig is an auto-generated flat version of Config.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatConfig struct {
+	PackerBuildName               *string           `mapstructure:"packer_build_name" cty:"packer_build_name" hcl:"packer_build_name"`
+	PackerBuilderType             *string           `mapstructure:"packer_builder_type" cty:"packer_builder_type" hcl:"packer_builder_type"`
+	PackerCoreVersion             *string           `mapstructure:"packer_core_version" cty:"packer_core_version" hcl:"packer_core_version"`
+	PackerDebug                   *bool             `mapstructure:"packer_debug" cty:"packer_debug" hcl:"packer_debug"`
+	PackerForce                   *bool             `mapstructure:"packer_force" cty:"packer_force" hcl:"packer_force"`
+	PackerOnError                 *string           `mapstructure:"packer_on_error" cty:"packer_on_error" hcl:"packer_on_error"`
+	PackerUserVars                map[string]string `mapstructure:"packer_user_variables" cty:"packer_user_variables" hcl:"packer_user_variables"`
+	PackerSensitiveVars           []string          `mapstructure:"packer_sensitive_variables" cty:"packer_sensitive_variables" hcl:"packer_sensitive_variables"`
+	InstancePrincipals            *bool             `mapstructure:"use_instance_principals" cty:"use_instance_principals" hcl:"use_instance_principals"`
+	AccessCfgFile                 *string           `mapstructure:"access_cfg_file" cty:"access_cfg_file" hcl:"access_cfg_file"`
+	AccessCfgFileAccount          *string           `mapstructure:"access_cfg_file_account" cty:"access_cfg_file_account" hcl:"access_cfg_file_account"`
+	UserID                        *string           `mapstructure:"user_ocid" cty:"user_ocid" hcl:"user_ocid"`
+	TenancyID                     *string           `mapstructure:"tenancy_ocid" cty:"tenancy_ocid" hcl:"tenancy_ocid"`
+	Region                        *string           `mapstructure:"region" cty:"region" hcl:"region"`
+	Fingerprint                   *string           `mapstructure:"fingerprint" cty:"fingerprint" hcl:"fingerprint"`
+	Key                           *string           `mapstructure:"key" cty:"key" hcl:"key"`
+	KeyFile                       *string           `mapstructure:"key_file" cty:"key_file" hcl:"key_file"`
+	PassPhrase                    *string           `mapstructure:"pass_phrase" cty:"pass_phrase" hcl:"pass_phrase"`
+	SecurityTokenFilePath         *string           `mapstructure:"security_token_file" cty:"security_token_file" hcl:"security_token_file"`
+	Namespace                     *string           `mapstructure:"namespace" cty:"namespace" hcl:"namespace"`
+	BucketName                    *string           `mapstructure:"bucket_name" required:"true" cty:"bucket_name" hcl:"bucket_name"`
+	ObjectName                    *string           `mapstructure:"object_name" cty:"object_name" hcl:"object_name"`
+	ExportFormat                  *string           `mapstructure:"export_format" cty:"export_format" hcl:"export_format"`
+	CreatePreauthenticatedRequest *bool             `mapstructure:"create_preauthenticated_request" cty:"create_preauthenticated_request" hcl:"create_preauthenticated_request"`
+	PreauthenticatedRequestExpiry *string           `mapstructure:"preauthenticated_request_expiry" cty:"preauthenticated_request_expiry" hcl:"preauthenticated_request_expiry"`
+}
+
+// FlatMapstructure returns a new FlatConfig.
+// FlatConfig is an auto-generated flat version of Config.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*Config) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatConfig)
+}
+
+// HCL2Spec returns the hcl spec of a Config.
+// This spec is used by HCL to read the fields of Config.
+// The decoded values from this spec will then be applied to a FlatConfig.
+func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"packer_build_name":               &hcldec.AttrSpec{Name: "packer_build_name", Type: cty.String, Required: false},
+		"packer_builder_type":             &hcldec.AttrSpec{Name: "packer_builder_type", Type: cty.String, Required: false},
+		"packer_core_version":             &hcldec.AttrSpec{Name: "packer_core_version", Type: cty.String, Required: false},
+		"packer_debug":                    &hcldec.AttrSpec{Name: "packer_debug", Type: cty.Bool, Required: false},
+		"packer_force":                    &hcldec.AttrSpec{Name: "packer_force", Type: cty.Bool, Required: false},
+		"packer_on_error":                 &hcldec.AttrSpec{Name: "packer_on_error", Type: cty.String, Required: false},
+		"packer_user_variables":           &hcldec.AttrSpec{Name: "packer_user_variables", Type: cty.Map(cty.String), Required: false},
+		"packer_sensitive_variables":      &hcldec.AttrSpec{Name: "packer_sensitive_variables", Type: cty.List(cty.String), Required: false},
+		"use_instance_principals":         &hcldec.AttrSpec{Name: "use_instance_principals", Type: cty.Bool, Required: false},
+		"access_cfg_file":                 &hcldec.AttrSpec{Name: "access_cfg_file", Type: cty.String, Required: false},
+		"access_cfg_file_account":         &hcldec.AttrSpec{Name: "access_cfg_file_account", Type: cty.String, Required: false},
+		"user_ocid":                       &hcldec.AttrSpec{Name: "user_ocid", Type: cty.String, Required: false},
+		"tenancy_ocid":                    &hcldec.AttrSpec{Name: "tenancy_ocid", Type: cty.String, Required: false},
+		"region":                          &hcldec.AttrSpec{Name: "region", Type: cty.String, Required: false},
+		"fingerprint":                     &hcldec.AttrSpec{Name: "fingerprint", Type: cty.String, Required: false},
+		"key":                             &hcldec.AttrSpec{Name: "key", Type: cty.String, Required: false},
+		"key_file":                        &hcldec.AttrSpec{Name: "key_file", Type: cty.String, Required: false},
+		"pass_phrase":                     &hcldec.AttrSpec{Name: "pass_phrase", Type: cty.String, Required: false},
+		"security_token_file":             &hcldec.AttrSpec{Name: "security_token_file", Type: cty.String, Required: false},
+		"namespace":                       &hcldec.AttrSpec{Name: "namespace", Type: cty.String, Required: false},
+		"bucket_name":                     &hcldec.AttrSpec{Name: "bucket_name", Type: cty.String, Required: false},
+		"object_name":                     &hcldec.AttrSpec{Name: "object_name", Type: cty.String, Required: false},
+		"export_format":                   &hcldec.AttrSpec{Name: "export_format", Type: cty.String, Required: false},
+		"create_preauthenticated_request": &hcldec.AttrSpec{Name: "create_preauthenticated_request", Type: cty.Bool, Required: false},
+		"preauthenticated_request_expiry": &hcldec.AttrSpec{Name: "preauthenticated_request_expiry", Type: cty.String, Required: false},
+	}
+	return s
+}
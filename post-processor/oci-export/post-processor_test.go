@@ -0,0 +1,133 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package ociexport
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	ocibuilder "github.com/hashicorp/packer-plugin-oracle/builder/oci"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+func testConfig() map[string]interface{} {
+	config := ocibuilder.TestAccessConfig()
+	config["bucket_name"] = "images"
+	return config
+}
+
+func TestPostProcessor_ImplementsPostProcessor(t *testing.T) {
+	var _ packersdk.PostProcessor = new(PostProcessor)
+}
+
+func TestArtifact_ImplementsArtifact(t *testing.T) {
+	var _ packersdk.Artifact = new(Artifact)
+}
+
+func TestPostProcessorConfigure(t *testing.T) {
+	var p PostProcessor
+	if err := p.Configure(testConfig()); err != nil {
+		t.Fatalf("Unexpected error in configuration %+v", err)
+	}
+
+	if p.config.ExportFormat != "OCI" {
+		t.Errorf("Expected default export_format OCI, got %s", p.config.ExportFormat)
+	}
+
+	if !strings.HasPrefix(p.config.ObjectName, "packer-") || !strings.HasSuffix(p.config.ObjectName, ".oci") {
+		t.Errorf("got default object_name %q, want 'packer-{{timestamp}}.oci'", p.config.ObjectName)
+	}
+
+	if p.config.PreauthenticatedRequestExpiry != 24*time.Hour {
+		t.Errorf("Expected default preauthenticated_request_expiry 24h, got %s", p.config.PreauthenticatedRequestExpiry)
+	}
+}
+
+func TestPostProcessorConfigure_ExportFormat(t *testing.T) {
+	raw := testConfig()
+	raw["export_format"] = "qcow2"
+
+	var p PostProcessor
+	if err := p.Configure(raw); err != nil {
+		t.Fatalf("Unexpected error in configuration %+v", err)
+	}
+
+	if p.config.ExportFormat != "QCOW2" {
+		t.Errorf("Expected export_format QCOW2, got %s", p.config.ExportFormat)
+	}
+
+	if !strings.HasSuffix(p.config.ObjectName, ".qcow2") {
+		t.Errorf("Expected object_name with .qcow2 extension, got %s", p.config.ObjectName)
+	}
+}
+
+func TestPostProcessorConfigure_Invalid(t *testing.T) {
+	raw := testConfig()
+	delete(raw, "bucket_name")
+	raw["export_format"] = "ISO"
+	raw["preauthenticated_request_expiry"] = "-1h"
+
+	var p PostProcessor
+	err := p.Configure(raw)
+	if err == nil {
+		t.Fatalf("Expected configuration errors")
+	}
+
+	for _, expected := range []string{"'bucket_name'", "'export_format'", "'preauthenticated_request_expiry'"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q to contain '%s'", err.Error(), expected)
+		}
+	}
+}
+
+func TestImageForRegion(t *testing.T) {
+	artifact := &packersdk.MockArtifact{
+		BuilderIdValue: "packer.oracle.oci",
+		IdValue:        "ocid1.image.oc1.iad.aaa",
+		StateValues: map[string]interface{}{
+			"images": map[string]string{
+				"us-ashburn-1": "ocid1.image.oc1.iad.aaa",
+				"us-phoenix-1": "ocid1.image.oc1.phx.aaa",
+			},
+		},
+	}
+
+	id, err := imageForRegion(artifact, "us-phoenix-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if id != "ocid1.image.oc1.phx.aaa" {
+		t.Errorf("Expected ocid1.image.oc1.phx.aaa, got %s", id)
+	}
+
+	if _, err := imageForRegion(artifact, "eu-frankfurt-1"); err == nil {
+		t.Errorf("Expected error for region without image")
+	}
+}
+
+func TestImageForRegion_NoImagesState(t *testing.T) {
+	artifact := &packersdk.MockArtifact{
+		BuilderIdValue: "packer.oracle.oci",
+		IdValue:        "ocid1.image.oc1.iad.aaa",
+	}
+
+	id, err := imageForRegion(artifact, "us-ashburn-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if id != "ocid1.image.oc1.iad.aaa" {
+		t.Errorf("Expected ocid1.image.oc1.iad.aaa, got %s", id)
+	}
+
+	for _, bad := range []string{
+		"ocid1.image.oc1.iad.aaa,ocid1.image.oc1.phx.aaa",
+		"ocid1.bootvolumebackup.oc1.iad.aaa",
+	} {
+		artifact.IdValue = bad
+		if _, err := imageForRegion(artifact, "us-ashburn-1"); err == nil {
+			t.Errorf("Expected error for artifact ID %q", bad)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+//go:generate packer-sdc mapstructure-to-hcl2 -type Config
+
+// Package ociimport contains a packersdk.PostProcessor implementation that
+// imports local disk images into Oracle Cloud Infrastructure (OCI) as custom
+// images.
+package ociimport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hcldec"
+	ocibuilder "github.com/hashicorp/packer-plugin-oracle/builder/oci"
+	"github.com/hashicorp/packer-plugin-sdk/common"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-sdk/template/config"
+	"github.com/hashicorp/packer-plugin-sdk/template/interpolate"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// BuilderId uniquely identifies the post-processor
+const BuilderId = "packer.post-processor.oracle-oci-import"
+
+var imageTypes = []string{"QCOW2", "VMDK"}
+
+type Config struct {
+	common.PackerConfig     `mapstructure:",squash"`
+	ocibuilder.AccessConfig `mapstructure:",squash"`
+
+	// Object Storage location the disk image is uploaded to before it is
+	// imported. The namespace defaults to the namespace of the tenancy and
+	// the object name to the name of the disk image file.
+	Namespace  string `mapstructure:"namespace"`
+	BucketName string `mapstructure:"bucket_name" required:"true"`
+	ObjectName string `mapstructure:"object_name"`
+	// If true the uploaded object is kept after the import.
+	SkipClean bool `mapstructure:"skip_clean"`
+
+	// Image
+	CompartmentID          string `mapstructure:"compartment_ocid"`
+	ImageName              string `mapstructure:"image_name"`
+	ImageType              string `mapstructure:"image_type"`
+	LaunchMode             string `mapstructure:"image_launch_mode"`
+	OperatingSystem        string `mapstructure:"operating_system"`
+	OperatingSystemVersion string `mapstructure:"operating_system_version"`
+
+	// Tagging
+	Tags map[string]string `mapstructure:"tags"`
+	// HCL cannot be decoded into an interface so for HCL templates you must use the DefinedTagsJson option,
+	// To be used with https://www.packer.io/docs/templates/hcl_templates/functions/encoding/jsonencode
+	// ref: https://github.com/hashicorp/hcl/issues/291#issuecomment-496347585
+	DefinedTagsJson string `mapstructure:"defined_tags_json" required:"false"`
+	// For JSON templates we keep the map[string]map[string]interface{}
+	DefinedTags map[string]map[string]interface{} `mapstructure:"defined_tags" required:"false" mapstructure-to-hcl2:",skip"`
+
+	ctx interpolate.Context
+}
+
+type PostProcessor struct {
+	config Config
+}
+
+func (p *PostProcessor) ConfigSpec() hcldec.ObjectSpec { return p.config.FlatMapstructure().HCL2Spec() }
+
+func (p *PostProcessor) Configure(raws ...interface{}) error {
+	err := config.Decode(&p.config, &config.DecodeOpts{
+		PluginType:         BuilderId,
+		Interpolate:        true,
+		InterpolateContext: &p.config.ctx,
+	}, raws...)
+	if err != nil {
+		return err
+	}
+
+	if p.config.DefinedTagsJson != "" {
+		if err := json.Unmarshal([]byte(p.config.DefinedTagsJson), &p.config.DefinedTags); err != nil {
+			return fmt.Errorf("Failed to unmarshal 'defined_tags_json': %s", err.Error())
+		}
+	}
+
+	var errs *packersdk.MultiError
+	if es := p.config.AccessConfig.Prepare(); len(es) > 0 {
+		errs = packersdk.MultiErrorAppend(errs, es...)
+	}
+
+	if p.config.CompartmentID == "" && p.config.ConfigProvider() != nil {
+		p.config.CompartmentID, _ = p.config.ConfigProvider().TenancyOCID()
+	}
+
+	if p.config.BucketName == "" {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("'bucket_name' must be specified"))
+	}
+
+	if p.config.ImageName == "" {
+		name, err := interpolate.Render("packer-{{timestamp}}", nil)
+		if err != nil {
+			errs = packersdk.MultiErrorAppend(errs,
+				fmt.Errorf("unable to parse image name: %s", err))
+		} else {
+			p.config.ImageName = name
+		}
+	}
+
+	p.config.ImageType = strings.ToUpper(p.config.ImageType)
+	if p.config.ImageType != "" && p.config.ImageType != "QCOW2" && p.config.ImageType != "VMDK" {
+		errs = packersdk.MultiErrorAppend(
+			errs, fmt.Errorf("'image_type' must be one of %s", strings.Join(imageTypes, ", ")))
+	}
+
+	// Validate LaunchMode
+	if p.config.LaunchMode != "" && p.config.LaunchMode != "NATIVE" && p.config.LaunchMode != "EMULATED" && p.config.LaunchMode != "PARAVIRTUALIZED" && p.config.LaunchMode != "CUSTOM" {
+		errs = packersdk.MultiErrorAppend(
+			errs, errors.New("LaunchMode must be one of NATIVE, EMULATED, PARAVIRTUALIZED, or CUSTOM"))
+	}
+
+	if errs != nil && len(errs.Errors) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (p *PostProcessor) PostProcess(ctx context.Context, ui packersdk.Ui, artifact packersdk.Artifact) (packersdk.Artifact, bool, bool, error) {
+	source, imageType, err := p.diskImage(artifact.Files())
+	if err != nil {
+		return nil, false, false, err
+	}
+
+	region, err := p.config.ConfigProvider().Region()
+	if err != nil {
+		return nil, false, false, err
+	}
+
+	driver, err := ocibuilder.NewDriverOCI(&ocibuilder.Config{
+		AccessConfig:       p.config.AccessConfig,
+		CompartmentID:      p.config.CompartmentID,
+		ImageCompartmentID: p.config.CompartmentID,
+		ImageName:          p.config.ImageName,
+		LaunchMode:         p.config.LaunchMode,
+		Tags:               p.config.Tags,
+		DefinedTags:        p.config.DefinedTags,
+	})
+	if err != nil {
+		return nil, false, false, err
+	}
+
+	namespace := p.config.Namespace
+	if namespace == "" {
+		namespace, err = driver.GetNamespace(ctx)
+		if err != nil {
+			return nil, false, false, err
+		}
+	}
+
+	objectName := p.config.ObjectName
+	if objectName == "" {
+		objectName = filepath.Base(source)
+	}
+
+	ui.Say(fmt.Sprintf("Uploading %s to '%s/%s'...", source, p.config.BucketName, objectName))
+
+	if err := driver.UploadObject(ctx, namespace, p.config.BucketName, objectName, source); err != nil {
+		return nil, false, false, fmt.Errorf("Error uploading %s: %s", source, err)
+	}
+
+	if !p.config.SkipClean {
+		defer func() {
+			ui.Say(fmt.Sprintf("Deleting uploaded object '%s/%s'...", p.config.BucketName, objectName))
+			if err := driver.DeleteObject(context.TODO(), namespace, p.config.BucketName, objectName); err != nil {
+				ui.Error(fmt.Sprintf("Error deleting uploaded object %s. Please delete it manually: %s", objectName, err))
+			}
+		}()
+	}
+
+	ui.Say(fmt.Sprintf("Importing %s image '%s'...", imageType, p.config.ImageName))
+
+	sourceDetails := core.ImageSourceViaObjectStorageTupleDetails{
+		NamespaceName:   &namespace,
+		BucketName:      &p.config.BucketName,
+		ObjectName:      &objectName,
+		SourceImageType: core.ImageSourceDetailsSourceImageTypeEnum(imageType),
+	}
+	if p.config.OperatingSystem != "" {
+		sourceDetails.OperatingSystem = &p.config.OperatingSystem
+	}
+	if p.config.OperatingSystemVersion != "" {
+		sourceDetails.OperatingSystemVersion = &p.config.OperatingSystemVersion
+	}
+
+	image, err := driver.ImportImage(ctx, region, sourceDetails)
+	if err != nil {
+		return nil, false, false, fmt.Errorf("Error importing image: %s", err)
+	}
+
+	if err := driver.WaitForImageImport(ctx, region, *image.Id); err != nil {
+		return nil, false, false, fmt.Errorf("Error waiting for image import to finish: %s", err)
+	}
+
+	if p.config.LaunchMode != "" {
+		ui.Say("Updating image schema...")
+		if _, err := driver.UpdateImageCapabilitySchema(ctx, *image.Id); err != nil {
+			return nil, false, false, fmt.Errorf("Error updating image schema: %s", err)
+		}
+	}
+
+	ui.Say(fmt.Sprintf("Imported image (%s).", *image.Id))
+
+	return ocibuilder.NewArtifact(image, map[string]string{region: *image.Id}, driver, nil), false, false, nil
+}
+
+// diskImage picks the disk image to import from the files of the upstream
+// artifact and determines its type.
+func (p *PostProcessor) diskImage(files []string) (string, string, error) {
+	for _, file := range files {
+		imageType := strings.ToUpper(strings.TrimPrefix(filepath.Ext(file), "."))
+		for _, t := range imageTypes {
+			if imageType == t {
+				if p.config.ImageType != "" && p.config.ImageType != imageType {
+					log.Printf("[WARN] %s looks like a %s image but 'image_type' is %s", file, imageType, p.config.ImageType)
+					return file, p.config.ImageType, nil
+				}
+				return file, imageType, nil
+			}
+		}
+	}
+
+	// Builders like QEMU do not always add an extension to the disk image.
+	if len(files) == 1 {
+		imageType := p.config.ImageType
+		if imageType == "" {
+			imageType = "QCOW2"
+		}
+		return files[0], imageType, nil
+	}
+
+	return "", "", fmt.Errorf("No QCOW2 or VMDK disk image found in artifact files: %s", strings.Join(files, ", "))
+}
@@ -0,0 +1,92 @@
+// Copyright IBM Corp. 2013, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package ociimport
+
+import (
+	"strings"
+	"testing"
+
+	ocibuilder "github.com/hashicorp/packer-plugin-oracle/builder/oci"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+func testConfig() map[string]interface{} {
+	config := ocibuilder.TestAccessConfig()
+	config["bucket_name"] = "images"
+	return config
+}
+
+func TestPostProcessor_ImplementsPostProcessor(t *testing.T) {
+	var _ packersdk.PostProcessor = new(PostProcessor)
+}
+
+func TestPostProcessorConfigure(t *testing.T) {
+	var p PostProcessor
+	if err := p.Configure(testConfig()); err != nil {
+		t.Fatalf("Unexpected error in configuration %+v", err)
+	}
+
+	if p.config.CompartmentID != "ocid1.tenancy.oc1..aaa" {
+		t.Errorf("Expected compartment_ocid to default to the tenancy, got %s", p.config.CompartmentID)
+	}
+
+	if !strings.HasPrefix(p.config.ImageName, "packer-") {
+		t.Errorf("got default ImageName %q, want image name 'packer-{{timestamp}}'", p.config.ImageName)
+	}
+}
+
+func TestPostProcessorConfigure_Invalid(t *testing.T) {
+	raw := testConfig()
+	delete(raw, "bucket_name")
+	raw["image_type"] = "VHD"
+	raw["image_launch_mode"] = "FAST"
+
+	var p PostProcessor
+	err := p.Configure(raw)
+	if err == nil {
+		t.Fatalf("Expected configuration errors")
+	}
+
+	for _, expected := range []string{"'bucket_name'", "'image_type'", "LaunchMode"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q to contain '%s'", err.Error(), expected)
+		}
+	}
+}
+
+func TestPostProcessorDiskImage(t *testing.T) {
+	cases := []struct {
+		name      string
+		imageType string
+		files     []string
+		file      string
+		wantType  string
+		wantErr   bool
+	}{
+		{"qcow2", "", []string{"output/disk.qcow2"}, "output/disk.qcow2", "QCOW2", false},
+		{"vmdk among other files", "", []string{"output/disk.ovf", "output/disk-1.vmdk"}, "output/disk-1.vmdk", "VMDK", false},
+		{"single file without extension", "", []string{"output/packer-ubuntu"}, "output/packer-ubuntu", "QCOW2", false},
+		{"single file with image_type", "VMDK", []string{"output/packer-ubuntu"}, "output/packer-ubuntu", "VMDK", false},
+		{"no disk image", "", []string{"output/disk.ovf", "output/disk.mf"}, "", "", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := PostProcessor{config: Config{ImageType: tc.imageType}}
+			file, imageType, err := p.diskImage(tc.files)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("Expected error, got %s (%s)", file, imageType)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if file != tc.file || imageType != tc.wantType {
+				t.Errorf("Expected %s (%s), got %s (%s)", tc.file, tc.wantType, file, imageType)
+			}
+		})
+	}
+}